@@ -3,18 +3,74 @@ package columns
 import (
 	"fmt"
 	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
 )
 
 // Aggregation is the interface for describing a calculated value in a footer
+//
+// A Result of NaN (e.g. the average of no values) is written as an empty cell
 type Aggregation interface {
 	AddValue(v interface{}) error
 	Name() string
 	Result() float64
 }
 
+// Valuer is implemented by aggregations with a result that isn't (always) a float64,
+// e.g. a string, a time.Time or a time.Duration
+//
+// When implemented Value is used instead of Result, a nil value is written as an empty cell
+type Valuer interface {
+	Value() interface{}
+}
+
+// WithStyle applies a style to the footer-cell of an aggregation
+func WithStyle(agg Aggregation, style *Style) Aggregation {
+	return &aggStyled{Aggregation: agg, style: style}
+}
+
+type aggStyled struct {
+	Aggregation
+	style *Style
+}
+
+func (st *aggStyled) Clone() Aggregation {
+	if c, ok := st.Aggregation.(Cloner); ok {
+		return WithStyle(c.Clone(), st.style)
+	}
+	return nil
+}
+
+func (st *aggStyled) Value() interface{} {
+	return aggValue(st.Aggregation)
+}
+
 // ErrInvalidType error for saying it's a value we can't "aggregate"
 var ErrInvalidType = fmt.Errorf("not a numerical value")
 
+// aggCell returns the result of the aggregation as a (styled) cell
+func aggCell(agg Aggregation) *CellData {
+	cell := Cell(aggValue(agg))
+	if st, ok := agg.(*aggStyled); ok {
+		cell.style = st.style
+	}
+	return cell
+}
+
+// aggValue returns the result of the aggregation as a cell-value (nil when there is no result)
+func aggValue(agg Aggregation) interface{} {
+	if v, ok := agg.(Valuer); ok {
+		return v.Value()
+	}
+	n := agg.Result()
+	if math.IsNaN(n) {
+		return nil
+	}
+	return n
+}
+
 func round(n float64, precision int) float64 {
 	decimals := math.Pow10(precision)
 	return math.Round(n*decimals) / decimals
@@ -25,7 +81,7 @@ func getNum(v interface{}) (float64, bool) {
 	case float64:
 		return n, true
 	case float32:
-		return float64(n), true
+		return float32to64(n), true
 	case int64:
 		return float64(n), true
 	case int32:
@@ -46,11 +102,32 @@ func getNum(v interface{}) (float64, bool) {
 		return float64(n), true
 	case uint:
 		return float64(n), true
+	case time.Duration:
+		return float64(n), true
 	case bool:
 		if n {
 			return 1, true
 		}
 		return 0, true
+	case nil, string:
+		return 0, false
+	}
+
+	if n, ok := bigNum(v); ok {
+		return n, true
+	}
+	if p := deref(v); isBig(p) || reflect.ValueOf(v).Kind() == reflect.Ptr { // pointers to (and big) numerical values
+		return getNum(p)
+	}
+	if n, ok := floating(v); ok { // named types, e.g. 'type Celsius float64'
+		return n, true
+	}
+	if digits, neg, ok := integer(v); ok {
+		n, _ := strconv.ParseFloat(digits, 64)
+		if neg {
+			n = -n
+		}
+		return n, true
 	}
 	return 0, false
 }
@@ -59,6 +136,28 @@ func getNum(v interface{}) (float64, bool) {
 type aggSum struct {
 	precision int
 	value     float64
+	durations durations
+}
+
+// durations keeps track of if all values are time.Duration (to return the result as a time.Duration)
+type durations struct {
+	count int
+	other bool
+}
+
+func (d *durations) add(v interface{}) {
+	if _, ok := v.(time.Duration); ok {
+		d.count++
+	} else {
+		d.other = true
+	}
+}
+
+func (d *durations) value(n float64) interface{} {
+	if d.count > 0 && !d.other {
+		return time.Duration(n)
+	}
+	return nil
 }
 
 // Sum creates an 'Aggregation' that 'sums' all valid values
@@ -72,13 +171,26 @@ func (sum *aggSum) Name() string {
 	return "Sum"
 }
 
+func (sum *aggSum) Clone() Aggregation {
+	return Sum(sum.precision)
+}
+
 func (sum *aggSum) Result() float64 {
 	return round(sum.value, sum.precision)
 }
 
+func (sum *aggSum) Value() interface{} {
+	if d := sum.durations.value(sum.value); d != nil {
+		return d
+	}
+	return sum.Result()
+}
+
 func (sum *aggSum) AddValue(v interface{}) error {
 	if n, ok := getNum(v); ok {
 		sum.value += n
+		sum.durations.add(v)
+		return nil
 	}
 	return ErrInvalidType
 }
@@ -88,6 +200,7 @@ type aggAvg struct {
 	precision int
 	total     float64
 	count     int
+	durations durations
 }
 
 // Avg creates an 'Aggregation' that 'averages' all valid values
@@ -101,14 +214,437 @@ func (avg *aggAvg) Name() string {
 	return "Average"
 }
 
+func (avg *aggAvg) Clone() Aggregation {
+	return Avg(avg.precision)
+}
+
 func (avg *aggAvg) Result() float64 {
+	if avg.count == 0 {
+		return math.NaN()
+	}
 	return round(avg.total/float64(avg.count), avg.precision)
 }
 
+func (avg *aggAvg) Value() interface{} {
+	if avg.count == 0 {
+		return nil
+	}
+	if d := avg.durations.value(math.Round(avg.total / float64(avg.count))); d != nil {
+		return d
+	}
+	return avg.Result()
+}
+
 func (avg *aggAvg) AddValue(v interface{}) error {
 	if n, ok := getNum(v); ok {
 		avg.total += n
 		avg.count++
+		avg.durations.add(v)
+		return nil
+	}
+	return ErrInvalidType
+}
+
+// MIN & MAX
+type aggMinMax struct {
+	name      string
+	precision int
+	max       bool
+	value     interface{}
+}
+
+// Min creates an 'Aggregation' with the smallest value
+//
+// Numbers (and times) are compared in their natural order, other values (e.g. "n/a")
+// are only compared when there are no numbers or times
+func Min(prec int) Aggregation {
+	return &aggMinMax{name: "Min", precision: prec}
+}
+
+// Max creates an 'Aggregation' with the largest value (see Min)
+func Max(prec int) Aggregation {
+	return &aggMinMax{name: "Max", precision: prec, max: true}
+}
+
+func (mm *aggMinMax) Name() string {
+	return mm.name
+}
+
+func (mm *aggMinMax) Clone() Aggregation {
+	return &aggMinMax{name: mm.name, precision: mm.precision, max: mm.max}
+}
+
+func (mm *aggMinMax) Result() float64 {
+	if n, ok := getNum(mm.value); ok {
+		return round(n, mm.precision)
+	}
+	return math.NaN()
+}
+
+func (mm *aggMinMax) Value() interface{} {
+	if _, ok := mm.value.(time.Duration); ok {
+		return mm.value
+	}
+	if _, ok := getNum(mm.value); ok {
+		return mm.Result()
+	}
+	return mm.value
+}
+
+func (mm *aggMinMax) AddValue(v interface{}) error {
+	if isEmpty(v) {
+		return ErrInvalidType
+	}
+	if mm.value == nil {
+		mm.value = v
+		return nil
+	}
+	switch next, current := isMeasurable(v), isMeasurable(mm.value); {
+	case next && !current: // numbers replace placeholders like "n/a"
+		mm.value = v
+		return nil
+	case !next && current:
+		return nil
+	}
+	comp := compareValue(Cell(v), Cell(mm.value), true)
+	if (mm.max && comp > 0) || (!mm.max && comp < 0) {
+		mm.value = v
+	}
+	return nil
+}
+
+// isMeasurable returns if 'v' is a number or a time (preferred over other values by Min and Max)
+func isMeasurable(v interface{}) bool {
+	if _, ok := deref(v).(time.Time); ok {
+		return true
+	}
+	_, ok := getNum(v)
+	return ok
+}
+
+// COUNT
+type aggCount struct {
+	name     string
+	nonEmpty bool
+	count    int
+}
+
+// Count creates an 'Aggregation' counting all values (including empty values)
+func Count() Aggregation {
+	return &aggCount{name: "Count"}
+}
+
+// CountNonEmpty creates an 'Aggregation' counting all values that are not empty (nil, a nil pointer or the zero time) or an empty string
+func CountNonEmpty() Aggregation {
+	return &aggCount{name: "Non-empty", nonEmpty: true}
+}
+
+func (cnt *aggCount) Name() string {
+	return cnt.name
+}
+
+func (cnt *aggCount) Clone() Aggregation {
+	return &aggCount{name: cnt.name, nonEmpty: cnt.nonEmpty}
+}
+
+func (cnt *aggCount) Result() float64 {
+	return float64(cnt.count)
+}
+
+func (cnt *aggCount) AddValue(v interface{}) error {
+	if cnt.nonEmpty && isBlank(v) {
+		return nil
+	}
+	cnt.count++
+	return nil
+}
+
+// COUNT DISTINCT
+type aggDistinct struct {
+	values map[string]bool
+}
+
+// CountDistinct creates an 'Aggregation' counting the number of different (non-empty) values
+func CountDistinct() Aggregation {
+	return &aggDistinct{
+		values: make(map[string]bool),
+	}
+}
+
+func (dist *aggDistinct) Name() string {
+	return "Distinct"
+}
+
+func (dist *aggDistinct) Clone() Aggregation {
+	return CountDistinct()
+}
+
+func (dist *aggDistinct) Result() float64 {
+	return float64(len(dist.values))
+}
+
+func (dist *aggDistinct) AddValue(v interface{}) error {
+	if key, ok := distinctKey(v); ok {
+		dist.values[key] = true
+	}
+	return nil
+}
+
+// distinctKey returns a key that is equal for equal values (false for empty values),
+// pointers are keyed on the value they point to
+func distinctKey(v interface{}) (string, bool) {
+	if isBlank(v) {
+		return "", false
+	}
+	v = deref(v)
+	return fmt.Sprintf("%T:%v", v, v), true
+}
+
+// PERCENTILE & MEDIAN
+type aggPercentile struct {
+	name       string
+	precision  int
+	percentile float64
+	values     []float64
+	durations  durations
+}
+
+// Percentile creates an 'Aggregation' with the 'p'-th percentile (0-100) of all valid values
+//
+// Values between two rows are linearly interpolated
+func Percentile(p float64, prec int) Aggregation {
+	return &aggPercentile{
+		name:       fmt.Sprintf("P%v", p),
+		precision:  prec,
+		percentile: p,
+	}
+}
+
+// Median creates an 'Aggregation' with the median (the 50th percentile) of all valid values
+func Median(prec int) Aggregation {
+	return &aggPercentile{
+		name:       "Median",
+		precision:  prec,
+		percentile: 50,
+	}
+}
+
+func (pct *aggPercentile) Name() string {
+	return pct.name
+}
+
+func (pct *aggPercentile) Clone() Aggregation {
+	return &aggPercentile{name: pct.name, precision: pct.precision, percentile: pct.percentile}
+}
+
+func (pct *aggPercentile) Result() float64 {
+	if len(pct.values) == 0 {
+		return math.NaN()
+	}
+	return round(pct.value(), pct.precision)
+}
+
+func (pct *aggPercentile) Value() interface{} {
+	if len(pct.values) == 0 {
+		return nil
+	}
+	if d := pct.durations.value(math.Round(pct.value())); d != nil {
+		return d
+	}
+	return pct.Result()
+}
+
+// value returns the (unrounded) percentile of the values
+func (pct *aggPercentile) value() float64 {
+	values := make([]float64, len(pct.values))
+	copy(values, pct.values)
+	sort.Float64s(values)
+
+	pos := pct.percentile / 100 * float64(len(values)-1)
+	if pos <= 0 {
+		return values[0]
+	}
+	if pos >= float64(len(values)-1) {
+		return values[len(values)-1]
+	}
+	i := int(pos)
+	frac := pos - float64(i)
+	return values[i] + (values[i+1]-values[i])*frac
+}
+
+func (pct *aggPercentile) AddValue(v interface{}) error {
+	if n, ok := getNum(v); ok {
+		pct.values = append(pct.values, n)
+		pct.durations.add(v)
+		return nil
 	}
 	return ErrInvalidType
 }
+
+// VARIANCE & STANDARD DEVIATION
+type aggVariance struct {
+	name      string
+	precision int
+	stddev    bool
+	count     int
+	mean      float64
+	m2        float64 // sum of squared differences from the mean (Welford)
+	durations durations
+}
+
+// Variance creates an 'Aggregation' with the (sample) variance of all valid values
+func Variance(prec int) Aggregation {
+	return &aggVariance{name: "Variance", precision: prec}
+}
+
+// StdDev creates an 'Aggregation' with the (sample) standard deviation of all valid values
+func StdDev(prec int) Aggregation {
+	return &aggVariance{name: "Std.dev", precision: prec, stddev: true}
+}
+
+func (vr *aggVariance) Name() string {
+	return vr.name
+}
+
+func (vr *aggVariance) Clone() Aggregation {
+	return &aggVariance{name: vr.name, precision: vr.precision, stddev: vr.stddev}
+}
+
+func (vr *aggVariance) Result() float64 {
+	if vr.count < 2 {
+		return math.NaN()
+	}
+	variance := vr.m2 / float64(vr.count-1)
+	if vr.stddev {
+		return round(math.Sqrt(variance), vr.precision)
+	}
+	return round(variance, vr.precision)
+}
+
+// Value returns the standard deviation of durations as a time.Duration,
+// the variance of durations (in squared nanoseconds) has no result
+func (vr *aggVariance) Value() interface{} {
+	if vr.count < 2 {
+		return nil
+	}
+	if vr.stddev {
+		if d := vr.durations.value(math.Round(math.Sqrt(vr.m2 / float64(vr.count-1)))); d != nil {
+			return d
+		}
+	} else if vr.durations.value(0) != nil {
+		return nil
+	}
+	return vr.Result()
+}
+
+func (vr *aggVariance) AddValue(v interface{}) error {
+	if n, ok := getNum(v); ok {
+		vr.count++
+		delta := n - vr.mean
+		vr.mean += delta / float64(vr.count)
+		vr.m2 += delta * (n - vr.mean)
+		vr.durations.add(v)
+		return nil
+	}
+	return ErrInvalidType
+}
+
+// FIRST & LAST
+type aggFirstLast struct {
+	name  string
+	last  bool
+	value interface{}
+}
+
+// First creates an 'Aggregation' with the first (non-empty) value
+func First() Aggregation {
+	return &aggFirstLast{name: "First"}
+}
+
+// Last creates an 'Aggregation' with the last (non-empty) value
+func Last() Aggregation {
+	return &aggFirstLast{name: "Last", last: true}
+}
+
+func (fl *aggFirstLast) Name() string {
+	return fl.name
+}
+
+func (fl *aggFirstLast) Clone() Aggregation {
+	return &aggFirstLast{name: fl.name, last: fl.last}
+}
+
+func (fl *aggFirstLast) Result() float64 {
+	if n, ok := getNum(fl.value); ok {
+		return n
+	}
+	return math.NaN()
+}
+
+func (fl *aggFirstLast) Value() interface{} {
+	return fl.value
+}
+
+func (fl *aggFirstLast) AddValue(v interface{}) error {
+	if isBlank(v) {
+		return ErrInvalidType
+	}
+	if fl.value == nil || fl.last {
+		fl.value = v
+	}
+	return nil
+}
+
+// MOST COMMON
+type aggMode struct {
+	counts map[string]int
+	values map[string]interface{}
+	order  []string
+}
+
+// MostCommon creates an 'Aggregation' with the most common (non-empty) value,
+// the first value is used when several values are equally common
+func MostCommon() Aggregation {
+	return &aggMode{
+		counts: make(map[string]int),
+		values: make(map[string]interface{}),
+	}
+}
+
+func (mode *aggMode) Name() string {
+	return "Most common"
+}
+
+func (mode *aggMode) Clone() Aggregation {
+	return MostCommon()
+}
+
+func (mode *aggMode) Result() float64 {
+	if n, ok := getNum(mode.Value()); ok {
+		return n
+	}
+	return math.NaN()
+}
+
+func (mode *aggMode) Value() interface{} {
+	best := ""
+	for _, key := range mode.order {
+		if best == "" || mode.counts[key] > mode.counts[best] {
+			best = key
+		}
+	}
+	return mode.values[best]
+}
+
+func (mode *aggMode) AddValue(v interface{}) error {
+	key, ok := distinctKey(v)
+	if !ok {
+		return ErrInvalidType
+	}
+	if _, ok := mode.counts[key]; !ok {
+		mode.order = append(mode.order, key)
+		mode.values[key] = v
+	}
+	mode.counts[key]++
+	return nil
+}
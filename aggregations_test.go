@@ -0,0 +1,79 @@
+package columns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregationsSkipEmpty(t *testing.T) {
+	a, b := "a", "a"
+	var nothing *string
+	values := []interface{}{"", nothing, nil, &a, &b, "a", "b"}
+
+	tests := []struct {
+		agg  Aggregation
+		want float64
+	}{
+		{CountDistinct(), 2}, // pointers are compared on their values
+		{Count(), 7},
+		{CountNonEmpty(), 4},
+	}
+	for _, tt := range tests {
+		for _, v := range values {
+			_ = tt.agg.AddValue(v)
+		}
+		if got := tt.agg.Result(); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.agg.Name(), got, tt.want)
+		}
+	}
+
+	mode := MostCommon()
+	for _, v := range append(values, "", "", "", nothing, nothing) {
+		_ = mode.AddValue(v)
+	}
+	if got := mode.(Valuer).Value(); deref(got) != "a" {
+		t.Errorf("MostCommon = %v, want a", got)
+	}
+
+	first, last := First(), Last()
+	for _, v := range append([]interface{}{"", nothing}, append(values, "", nothing)...) {
+		_ = first.AddValue(v)
+		_ = last.AddValue(v)
+	}
+	if got := first.(Valuer).Value(); deref(got) != "a" {
+		t.Errorf("First = %v, want a", got)
+	}
+	if got := last.(Valuer).Value(); got != "b" {
+		t.Errorf("Last = %v, want b", got)
+	}
+}
+
+func TestAggregationsDurations(t *testing.T) {
+	tests := []struct {
+		agg  Aggregation
+		want interface{}
+	}{
+		{Sum(0), 3 * time.Hour},
+		{Median(0), time.Hour},
+		{Percentile(100, 0), 2 * time.Hour},
+		{StdDev(0), time.Hour},
+		{Variance(0), nil}, // squared durations have no unit
+	}
+	for _, tt := range tests {
+		for _, v := range []interface{}{2 * time.Hour, time.Hour, time.Duration(0)} {
+			_ = tt.agg.AddValue(v)
+		}
+		if got := aggValue(tt.agg); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.agg.Name(), got, tt.want)
+		}
+	}
+}
+
+func TestColorFuncDuration(t *testing.T) {
+	if v := getValueForColorFunc(5 * time.Second); v != 5*time.Second {
+		t.Errorf("ColorFunc got %T, want time.Duration", v)
+	}
+	if v := getValueForColorFunc(int64(5)); v != 5.0 {
+		t.Errorf("ColorFunc got %T, want float64", v)
+	}
+}
@@ -1,11 +1,16 @@
 package columns
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/ninlil/ansi"
+)
 
 // CellData contains the cell value and it's assigned styling
 type CellData struct {
 	value interface{}
 	style *Style
+	span  int
 }
 
 // Cell create a cell that can be colored, prefixed or suffixed
@@ -15,6 +20,38 @@ func Cell(value interface{}) *CellData {
 	}
 }
 
+// toCell returns the value as a cell (unless it already is one)
+func toCell(v interface{}) *CellData {
+	if c, ok := v.(*CellData); ok {
+		return c
+	}
+	return Cell(v)
+}
+
+// Span creates a cell spanning 'n' columns (e.g. a section title)
+//
+// A spanning cell doesn't affect the size of the columns, unless it doesn't fit
+func Span(n int, value interface{}) *CellData {
+	return &CellData{
+		value: value,
+		span:  n,
+	}
+}
+
+// Span makes the cell span 'n' columns
+func (cell *CellData) Span(n int) *CellData {
+	cell.span = n
+	return cell
+}
+
+// columns returns the number of columns used by the cell
+func (cell *CellData) columns() int {
+	if cell.span > 1 {
+		return cell.span
+	}
+	return 1
+}
+
 // Style applies a style (from the NewStyle-function) to a specific cell
 func (cell *CellData) Style(style *Style) *CellData {
 	cell.style = style
@@ -42,54 +79,125 @@ func (cell *CellData) suffix(style *Style) string {
 }
 
 func (cell *CellData) isEmpty() bool {
-	return cell == nil || cell.value == nil
+	return cell == nil || isEmpty(cell.value)
 }
 
-func (cw *Writer) writeCell(c *CellData, col *column) {
-
-	var txt, prefix, suffix string
-	var sizeI, sizeF, lenPrefix, lenSuffix int
+// cellLines returns the aligned text of a cell, one entry per output line
+//
+// Texts are split on newlines and, depending on the column, truncated or wrapped
+func (cw *Writer) cellLines(c *CellData, col *column) []string {
+	if c.isEmpty() {
+		return []string{""}
+	}
 
-	if c == nil || c.value == nil {
-		txt = strings.Repeat(space, col.outerSize())
-		lenPrefix = col.sizePrefix
-		lenSuffix = col.sizeSuffix
-	} else {
-		txt, _, sizeI, sizeF = cw.format(c)
-		if col.sizeDot > 0 && sizeF == 0 { // add space to integer values where other rows have a decimal separator
-			txt += " "
+	txt, size, sizeI, sizeF := cw.format(c, col.style)
+	if sizeI > 0 || sizeF > 0 {
+		dot := size - sizeI - sizeF // 1 when the value has a decimal separator
+		if (col.maxWidth > 0 || cw.fixed()) && (sizeI > col.sizeI || dot+sizeF > col.sizeDot+col.sizeF) {
+			return []string{cw.overflow(col.sizeValue)}
 		}
 		if sizeI > 0 && col.sizeI > 0 {
-			txt = strings.Repeat(space, col.sizeI-sizeI) + txt
+			txt = spaces(col.sizeI-sizeI) + txt
 		}
-		if (sizeI > 0 || sizeF > 0) && col.sizeF > 0 {
-			txt = txt + strings.Repeat(space, col.sizeF-sizeF)
+		// pad where other rows have a decimal separator and decimals
+		txt += spaces(col.sizeDot + col.sizeF - dot - sizeF)
+		return []string{txt}
+	}
+
+	lines := strings.Split(txt, "\n")
+	size = col.maxWidth
+	if cw.fixed() && (size <= 0 || size > col.innerSize()) {
+		size = col.innerSize()
+	}
+	if size <= 0 {
+		return lines
+	}
+
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if col.truncate == Wrap {
+			result = append(result, wrap(line, size)...)
+		} else {
+			result = append(result, truncate(line, size, col.truncate, cw.Ellipsis))
 		}
+	}
+	return result
+}
 
-		prefix = c.prefix(col.style)
-		suffix = c.suffix(col.style)
+// writeCell writes one line of a cell, prefix and suffix are only written on the first line
+func (cw *Writer) writeCell(c *CellData, col *column, txt string, first bool, rowStyles []*Style) {
 
-		lenPrefix = len([]rune(prefix))
-		lenSuffix = len([]rune(suffix))
+	var prefix, suffix string
+	if first && !c.isEmpty() {
+		prefix = c.prefix(col.style)
+		suffix = cw.suffixOf(c, col.style)
+	}
+	if cw.fixed() { // keep the fixed layout
+		prefix = truncate(prefix, col.sizePrefix, TruncateEnd, cw.Ellipsis)
+		suffix = truncate(suffix, col.sizeSuffix, TruncateEnd, cw.Ellipsis)
 	}
 
+	var color string
 	if cw.useColor {
-		_, _ = cw.bufwr.WriteString(c.beginStyle(col))
+		color = c.beginStyle(col, rowStyles)
+		cw.print(color)
 	}
 
 	if col.sizePrefix > 0 {
-		_, _ = cw.bufwr.WriteString(prefix)
-		_, _ = cw.bufwr.WriteString(strings.Repeat(space, col.sizePrefix-lenPrefix))
+		cw.print(prefix)
+		cw.print(spaces(col.sizePrefix - width(prefix)))
 	}
 
-	_, _ = cw.bufwr.WriteString(pad(txt, col.innerSize(), col.align, ' '))
+	cw.print(pad(txt, col.innerSize(), col.align, ' '))
 
 	if col.sizeSuffix > 0 {
-		_, _ = cw.bufwr.WriteString(suffix)
-		_, _ = cw.bufwr.WriteString(strings.Repeat(space, col.sizeSuffix-lenSuffix))
+		cw.print(suffix)
+		cw.print(spaces(col.sizeSuffix - width(suffix)))
 	}
 
+	if color != "" {
+		cw.print(ansi.Default.String())
+	}
+}
+
+// spanLast returns the index of the last column covered by the spanning cell starting at column 'i'
+func (cw *Writer) spanLast(c *CellData, i int) int {
+	last := i + c.span - 1
+	if last >= cw.n {
+		last = cw.n - 1
+	}
+	return last
+}
+
+// spanText returns the text of a spanning cell, including the prefix and suffix
+func (cw *Writer) spanText(c *CellData) string {
+	if c.isEmpty() {
+		return ""
+	}
+	txt, _, _, _ := cw.format(c, nil)
+	return c.prefix(nil) + txt + cw.suffixOf(c, nil)
+}
+
+// spanLines returns the lines of a spanning cell, truncated to 'size'
+func (cw *Writer) spanLines(c *CellData, col *column, size int) []string {
+	lines := strings.Split(cw.spanText(c), "\n")
+	for i, line := range lines {
+		lines[i] = truncate(line, size, TruncateEnd, cw.Ellipsis)
+	}
+	return lines
+}
+
+// writeSpan writes one line of a cell spanning several columns (using the first column's alignment)
+func (cw *Writer) writeSpan(c *CellData, col *column, txt string, size int, rowStyles []*Style) {
+	var color string
 	if cw.useColor {
-		_, _ = cw.bufwr.WriteString(c.endStyle(col))
+		color = c.beginStyle(col, rowStyles)
+		cw.print(color)
+	}
+
+	cw.print(pad(txt, size, col.align, ' '))
+
+	if color != "" {
+		cw.print(ansi.Default.String())
 	}
 }
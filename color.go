@@ -0,0 +1,55 @@
+package columns
+
+import (
+	"io"
+	"os"
+)
+
+// ColorMode controls if styles are written as ANSI colors
+type ColorMode int
+
+// Color modes
+const (
+	ColorAuto   ColorMode = iota // colors when writing to a terminal (honoring NO_COLOR, FORCE_COLOR and TERM=dumb)
+	ColorAlways                  // always write colors (e.g. to a pipe that understands ANSI)
+	ColorNever                   // never write colors
+)
+
+// Color sets if styles should be written as ANSI colors (default ColorAuto)
+func (cw *Writer) Color(mode ColorMode) {
+	switch mode {
+	case ColorAlways:
+		cw.useColor = true
+	case ColorNever:
+		cw.useColor = false
+	default:
+		cw.useColor = autoColor(cw.terminal != nil)
+	}
+}
+
+// fileDescriptor is implemented by *os.File and writers wrapping a file (e.g. colorable)
+type fileDescriptor interface {
+	Fd() uintptr
+}
+
+// terminalOf returns 'writer' if it is a terminal (nil otherwise)
+func terminalOf(writer io.Writer) fileDescriptor {
+	if f, ok := writer.(fileDescriptor); ok && isTerminal(f) {
+		return f
+	}
+	return nil
+}
+
+// autoColor decides on using colors based on the environment (https://no-color.org, https://force-color.org)
+func autoColor(terminal bool) bool {
+	if v := os.Getenv("FORCE_COLOR"); v != "" && v != "0" && v != "false" {
+		return true
+	}
+	if v := os.Getenv("NO_COLOR"); v != "" {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return terminal
+}
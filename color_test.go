@@ -0,0 +1,27 @@
+package columns
+
+import "testing"
+
+func TestAutoColor(t *testing.T) {
+	tests := []struct {
+		force, no string
+		terminal  bool
+		want      bool
+	}{
+		{"", "", true, true},
+		{"", "", false, false},
+		{"1", "", false, true},
+		{"", "1", true, false},
+		{"0", "", false, false},
+		{"false", "", false, false},
+		{"1", "1", false, true}, // forced colors win
+	}
+	t.Setenv("TERM", "xterm")
+	for _, tt := range tests {
+		t.Setenv("FORCE_COLOR", tt.force) // set but empty is not forced
+		t.Setenv("NO_COLOR", tt.no)
+		if got := autoColor(tt.terminal); got != tt.want {
+			t.Errorf("FORCE_COLOR=%q NO_COLOR=%q terminal=%v: %v, want %v", tt.force, tt.no, tt.terminal, got, tt.want)
+		}
+	}
+}
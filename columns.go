@@ -2,15 +2,19 @@ package columns
 
 import (
 	"bufio"
+	"fmt"
 	"io"
-	"os"
 )
 
 // Writer is the main class capable of printing columns with headers, footers, dynamic styling and more
 type Writer struct {
-	HeaderSeparator   bool // Add a header-separator between header and values
-	ThousandSeparator rune // Change (or remove) the automatic thousand-separator (default ' ', disable when 0)
-	DecimalSeparator  rune // Change the decimal separator (default '.')
+	HeaderSeparator   bool   // Add a header-separator between header and values
+	ThousandSeparator rune   // Change (or remove) the automatic thousand-separator (default ' ', disable when 0)
+	DecimalSeparator  rune   // Change the decimal separator (default '.')
+	Strict            bool   // Write fails if a row has more (or fewer) values than there are columns
+	Ellipsis          string // Marks where a text was truncated (default '…')
+	Overflow          rune   // Replaces numerical values that are wider than allowed (default '#')
+	SuppressRepeats   bool   // Only write the values of the GroupBy-columns on the first row of each group
 
 	writer  io.Writer
 	bufwr   *bufio.Writer
@@ -18,27 +22,69 @@ type Writer struct {
 	columns []*column
 	spacers []string
 	headers []string
-	data    [][]*CellData
+	groups  []headerGroup
+	data    []*dataRow
 
 	head int
 	tail int
 
-	useColor bool
+	grouping []int    // sizes of the digit-groups (see Locale)
+	negative Negative // how negative numbers are written
 
-	aggOrder []string
+	stream     bool       // streaming mode enabled
+	streamMode StreamMode // how column widths behave once streaming has started
+	sample     int        // number of rows buffered before streaming starts
+	started    bool       // headers (and any buffered rows) have been written
+
+	useColor    bool
+	terminal    fileDescriptor // set when writing to a terminal
+	width       int            // width to fit the table into (0 for the terminal width)
+	renderer    Renderer
+	theme       *Theme
+	rowFunc     RowFunc
+	headerStyle *Style // style of all headers
+	zebra       *Style
+	printed     int // number of rows printed (for the zebra striping)
+
+	aggOrder    []string
+	labels      map[string]string // names written for the aggregations
+	labelColumn int               // column to write the aggregation names in (1-based, 0 after the last column)
+	footerRows  []*dataRow
+	sortBy      [][]int // sorts waiting for columns calculated when flushed
+	groupBy     []int   // 0-based columns to group the rows by
+
+	rows int   // number of rows written
+	err  error // first error, all output stops once set
 }
 
+// Errors reported by Write when the Writer is Strict
+var (
+	ErrTooManyValues = fmt.Errorf("too many values")
+	ErrTooFewValues  = fmt.Errorf("too few values")
+)
+
 type column struct {
-	sizeHeader   int // Size of the header
-	sizeValue    int // Max size of all values
-	sizeI        int // Max size of Integer-part (not including decimal separator)
-	sizeDot      int // 0 or 1 depending on if sizeF > 0
-	sizeF        int // Max size of Decimal-part (not including decimal separator)
-	sizePrefix   int // Max size of all prefixes
-	sizeSuffix   int // Max size of all suffixes
-	align        alignment
-	style        *Style
-	aggregations map[string]Aggregation
+	sizeHeader    int // Size of the header
+	sizeValue     int // Max size of all values
+	sizeI         int // Max size of Integer-part (not including decimal separator)
+	sizeDot       int // 0 or 1 depending on if any value has a decimal separator
+	sizeF         int // Max size of Decimal-part (not including decimal separator, including the end of negative numbers)
+	sizePrefix    int // Max size of all prefixes
+	sizeSuffix    int // Max size of all suffixes
+	maxWidth      int // Max size of values (0 for no limit)
+	truncate      Truncate
+	priority      int // Columns with lower priority are shrunk first to fit the width
+	minWidth      int // Never shrink the column below this size
+	align         Alignment
+	style         *Style
+	headerAlign   Alignment // Alignment of the header (0 to use the column alignment)
+	compute       ComputeFunc
+	computeTotals TotalsFunc
+	transform     *transform
+	compare       CompareFunc           // custom order for Sort
+	compareText   func(a, b string) int // order of the strings for Sort (nil for byte-wise)
+	headerStyle   *Style
+	aggregations  map[string]Aggregation
 }
 
 func (col *column) outerSize() int {
@@ -53,13 +99,14 @@ func (col *column) innerSize() int {
 	return col.outerSize() - col.sizePrefix - col.sizeSuffix
 }
 
-type alignment rune
+// Alignment of a column
+type Alignment rune
 
 // Alignment symbols
 const (
-	AlignLeft   alignment = '<'
-	AlignRight  alignment = '>'
-	AlignMiddle alignment = '^'
+	AlignLeft   Alignment = '<'
+	AlignRight  Alignment = '>'
+	AlignMiddle Alignment = '^'
 )
 
 // New creates a Writer based on the 'format'
@@ -68,19 +115,17 @@ const (
 // any other characters are padding between,
 // spaces as padding are not added automatically
 func New(writer io.Writer, format string) *Writer {
-	useColor := false
-
-	if writer == os.Stdout {
-		if fileInfo, _ := os.Stdout.Stat(); (fileInfo.Mode() & os.ModeCharDevice) != 0 {
-			useColor = true
-		}
-	}
+	terminal := terminalOf(writer)
 
 	cw := Writer{
 		writer:            writer,
 		ThousandSeparator: ' ',
 		DecimalSeparator:  '.',
-		useColor:          useColor,
+		negative:          NegativeSpaced,
+		Ellipsis:          "…",
+		Overflow:          '#',
+		useColor:          autoColor(terminal != nil),
+		terminal:          terminal,
 		head:              -1,
 		tail:              -1,
 	}
@@ -90,7 +135,7 @@ func New(writer io.Writer, format string) *Writer {
 		case rune(AlignLeft), rune(AlignMiddle), rune(AlignRight):
 			cw.spacers = append(cw.spacers, string(spacer))
 			spacer = spacer[:0]
-			cw.columns = append(cw.columns, &column{align: alignment(ch)})
+			cw.columns = append(cw.columns, &column{align: Alignment(ch)})
 
 		default:
 			spacer = append(spacer, ch)
@@ -118,7 +163,32 @@ func (cw *Writer) Tail(n int) {
 	}
 }
 
-// Separator sets the 'thousand' and 'decimal' separators
+// StreamMode controls how column widths behave once streaming has started
+type StreamMode int
+
+// Stream modes
+const (
+	StreamGrow  StreamMode = iota // columns grow when wider values arrive (earlier rows are not realigned)
+	StreamFixed                   // widths are fixed after the sample, wider values are truncated (numbers overflow)
+)
+
+// Stream makes the Writer print rows as they are written instead of buffering the whole table
+//
+// The first 'sample' rows are buffered to calculate the column widths, after that
+// every row is written directly. Sort, Head and Tail are ignored in streaming mode,
+// footers are still calculated and printed by Flush.
+func (cw *Writer) Stream(sample int, mode StreamMode) {
+	cw.stream = true
+	cw.sample = sample
+	cw.streamMode = mode
+}
+
+// fixed returns if the column widths can no longer change (wider values are truncated or overflow)
+func (cw *Writer) fixed() bool {
+	return cw.stream && cw.started && cw.streamMode == StreamFixed
+}
+
+// Separator sets the 'thousand' and 'decimal' separators (see also Locale)
 func (cw *Writer) Separator(thousand, decimal rune) {
 	cw.ThousandSeparator = thousand
 	cw.DecimalSeparator = decimal
@@ -126,13 +196,14 @@ func (cw *Writer) Separator(thousand, decimal rune) {
 
 // Headers sets text-headers to each column
 //
-// More headers than columns defined in 'New' will be ignored
+// More headers than columns defined in 'New' will be ignored,
+// headers containing newlines are written on multiple lines
 func (cw *Writer) Headers(titles ...string) {
 	cw.headers = make([]string, cw.n)
 	for i, hdr := range titles {
 		if i < len(cw.headers) {
 			cw.headers[i] = hdr
-			cw.columns[i].sizeHeader = len([]rune(hdr))
+			cw.columns[i].sizeHeader = textWidth(hdr)
 		}
 	}
 }
@@ -171,49 +242,136 @@ func (cw *Writer) Style(i int, style *Style) {
 
 // Write a line/row to the Writer
 //
-// Sortable datatypes are string, int, int64, and float64
-// other datatypes will be printed using fmt.Sprintf("%v")
+// Sortable datatypes are strings, times and numbers of all types (integers and big-numbers
+// are compared exactly), other datatypes will be printed using fmt.Sprintf("%v")
 //
 // More values than columns defined in 'New' will be ignored
-func (cw *Writer) Write(data ...interface{}) {
-	row := make([]*CellData, cw.n)
+//
+// In streaming mode the row is printed directly (once the sample is complete)
+//
+// Use a single RowData (from the Row-function) to style the entire row.
+//
+// When Strict, a row not matching the columns is dropped and reported with an error
+// (ErrTooManyValues or ErrTooFewValues), the following rows are still accepted.
+// The first error from writing is returned and no more rows will be accepted after that
+func (cw *Writer) Write(data ...interface{}) error {
+	if cw.err != nil {
+		return cw.err
+	}
+
+	row := &dataRow{}
+	if len(data) == 1 {
+		if r, ok := data[0].(*RowData); ok {
+			data = r.values
+			row.style = r.style
+		}
+	}
+
+	cells := make([]*CellData, len(data))
+	count := 0 // number of columns used, including spanned columns
 	for i, o := range data {
-		if i < cw.n {
-			var cell *CellData
+		cells[i] = toCell(o)
+		count += cells[i].columns()
+	}
 
-			if c, ok := o.(*CellData); ok {
-				cell = c
-			} else {
-				cell = Cell(o)
-			}
+	cw.rows++
+	if expected := cw.n - cw.computed(); cw.Strict && count != expected {
+		if count > expected { // the row is dropped, later rows are still accepted
+			return fmt.Errorf("row %d: %w (%d, expected %d)", cw.rows, ErrTooManyValues, count, expected)
+		}
+		return fmt.Errorf("row %d: %w (%d, expected %d)", cw.rows, ErrTooFewValues, count, expected)
+	}
 
-			for _, agg := range cw.columns[i].aggregations {
-				_ = agg.AddValue(cell.value)
-			}
+	row.cells = make([]*CellData, cw.n)
+	i := 0
+	for _, cell := range cells {
+		for i < cw.n && cw.columns[i].isComputed() { // computed columns get no values
+			i++
+		}
+		if i >= cw.n {
+			break
+		}
+		row.cells[i] = cell
+		i += cell.columns()
+	}
+	cw.computeRow(row)
 
+	for i, cell := range row.cells {
+		if cell == nil || cell.span > 1 { // spanning cells are sized when flushed
+			continue
+		}
+		if cw.columns[i].lateComputed() { // calculated (and sized) when flushed
+			continue
+		}
+
+		for _, agg := range cw.columns[i].aggregations {
+			_ = agg.AddValue(cell.value)
+		}
+
+		if !cw.started || cw.streamMode == StreamGrow {
 			cw.columns[i].ensureSize(cw, cell, cw.columns[i].style)
-			row[i] = cell
 		}
 	}
-	cw.data = append(cw.data, row)
+
+	if row.style == nil && cw.rowFunc != nil {
+		row.style = cw.rowFunc(row.values())
+	}
+
+	if !cw.stream {
+		cw.data = append(cw.data, row)
+		return nil
+	}
+
+	if !cw.started {
+		cw.data = append(cw.data, row)
+		if len(cw.data) >= cw.sample {
+			cw.fitSizes()
+			cw.begin()
+			for _, buffered := range cw.data {
+				cw.writeRow(buffered)
+			}
+			cw.data = nil
+			cw.flushOutput()
+		}
+		return cw.err
+	}
+
+	if cw.streamMode == StreamGrow {
+		cw.fitSizes()
+	}
+	cw.writeRow(row)
+	cw.flushOutput()
+	return cw.err
 }
 
 func (col *column) ensureSize(cw *Writer, cell *CellData, style *Style) {
 
 	if txt := cell.prefix(style); txt != "" {
-		l := len([]rune(txt))
+		l := width(txt)
 		if col.sizePrefix < l {
 			col.sizePrefix = l
 		}
 	}
-	if txt := cell.suffix(style); txt != "" {
-		l := len([]rune(txt))
+	if txt := cw.suffixOf(cell, style); txt != "" {
+		l := width(txt)
 		if col.sizeSuffix < l {
 			col.sizeSuffix = l
 		}
 	}
 
-	_, size, sizeI, sizeF := cw.format(cell)
+	_, size, sizeI, sizeF := cw.format(cell, style)
+	dot := size - sizeI - sizeF // 1 when the value has a decimal separator
+
+	if col.maxWidth > 0 {
+		if size > col.maxWidth {
+			size = col.maxWidth
+		}
+		if sizeI > 0 || sizeF > 0 {
+			if maxInt(col.sizeI, sizeI)+maxInt(col.sizeDot, dot)+maxInt(col.sizeF, sizeF) > col.maxWidth {
+				sizeI, sizeF, dot = 0, 0, 0 // will not fit when aligned, rendered as overflow
+			}
+		}
+	}
 
 	if col.sizeValue < size {
 		col.sizeValue = size
@@ -223,6 +381,18 @@ func (col *column) ensureSize(cw *Writer, cell *CellData, style *Style) {
 	}
 	if col.sizeF < sizeF {
 		col.sizeF = sizeF
+	}
+	if dot > 0 && (sizeI > 0 || sizeF > 0) {
 		col.sizeDot = 1
 	}
 }
+
+// fit makes sure the value-size can hold the aligned integer- and decimal-parts
+func (col *column) fit() {
+	if col.sizeI > 0 || col.sizeF > 0 {
+		size := col.sizeI + col.sizeDot + col.sizeF
+		if col.sizeValue < size {
+			col.sizeValue = size
+		}
+	}
+}
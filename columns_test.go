@@ -0,0 +1,38 @@
+package columns
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	var buf bytes.Buffer
+	cw := New(&buf, "<|>")
+	cw.Strict = true
+	cw.Headers("Name", "Moons")
+
+	if err := cw.Write("Earth", 1); err != nil {
+		t.Fatalf("Write = %v", err)
+	}
+	if err := cw.Write("Mars", 2, "red"); !errors.Is(err, ErrTooManyValues) {
+		t.Errorf("Write = %v, want %v", err, ErrTooManyValues)
+	}
+	if err := cw.Write("Venus"); !errors.Is(err, ErrTooFewValues) {
+		t.Errorf("Write = %v, want %v", err, ErrTooFewValues)
+	}
+	if err := cw.Write("Jupiter", 95); err != nil {
+		t.Errorf("Write after a dropped row = %v", err)
+	}
+	if err := cw.Flush(); err != nil {
+		t.Fatalf("Flush = %v", err)
+	}
+	if cw.Err() != nil {
+		t.Errorf("Err = %v, want nil", cw.Err())
+	}
+
+	want := "Name   |Moons\nEarth  |    1\nJupiter|   95\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package columns
+
+// ComputeFunc calculates the value of a column from the values of the row
+type ComputeFunc func(values []interface{}) interface{}
+
+// TotalsFunc calculates the value of a column from the values of the row and the
+// totals (the sums of the numerical values) of each column
+type TotalsFunc func(values []interface{}, totals []float64) interface{}
+
+// Compute makes column 'i' (1-based) calculated from the other values of each row
+//
+// No value is given to Write for a computed column, 'values' contains all columns
+// (computed columns to the left are already calculated).
+// The result is sized, sorted and aggregated like any other value
+//
+//	cw.Compute(4, func(values []interface{}) interface{} {
+//		return values[1].(float64) / values[2].(float64)
+//	})
+func (cw *Writer) Compute(i int, fn ComputeFunc) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].compute = fn
+	}
+}
+
+// ComputeTotals makes column 'i' (1-based) calculated from the values of the row and the totals
+// of all columns (e.g. percent of total), the values are calculated when flushed
+//
+// Computed totals are not available in streaming mode
+func (cw *Writer) ComputeTotals(i int, fn TotalsFunc) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].computeTotals = fn
+	}
+}
+
+func (col *column) isComputed() bool {
+	return col.compute != nil || col.lateComputed()
+}
+
+// lateComputed returns if the column is calculated when flushed
+func (col *column) lateComputed() bool {
+	return col.computeTotals != nil || col.transform != nil
+}
+
+// computed returns the number of computed columns
+func (cw *Writer) computed() int {
+	n := 0
+	for _, col := range cw.columns {
+		if col.isComputed() {
+			n++
+		}
+	}
+	return n
+}
+
+// covered returns which columns are covered by a spanning cell
+func (row *dataRow) covered() []bool {
+	covered := make([]bool, len(row.cells))
+	for i, c := range row.cells {
+		if c != nil && c.span > 1 {
+			for j := i + 1; j < i+c.span && j < len(covered); j++ {
+				covered[j] = true
+			}
+		}
+	}
+	return covered
+}
+
+// computeRow calculates the computed columns of a row
+func (cw *Writer) computeRow(row *dataRow) {
+	covered := row.covered()
+	for i, col := range cw.columns {
+		if col.compute != nil && !covered[i] && row.cells[i] == nil {
+			row.cells[i] = toCell(col.compute(row.values()))
+		}
+	}
+}
+
+// computeTotals calculates the columns depending on the totals and adds them to the aggregations and sizes
+func (cw *Writer) computeTotals() {
+	if cw.stream {
+		return
+	}
+
+	var cols []int
+	for i, col := range cw.columns {
+		if col.computeTotals != nil {
+			cols = append(cols, i)
+		}
+	}
+	if len(cols) == 0 {
+		return
+	}
+
+	totals := make([]float64, cw.n)
+	for _, row := range cw.data {
+		for i, c := range row.cells {
+			if c == nil || c.span > 1 {
+				continue
+			}
+			if n, ok := getNum(c.value); ok {
+				totals[i] += n
+			}
+		}
+	}
+
+	for _, row := range cw.data {
+		covered := row.covered()
+		for _, i := range cols {
+			if covered[i] || row.cells[i] != nil {
+				continue
+			}
+			cw.setComputed(row, i, toCell(cw.columns[i].computeTotals(row.values(), totals)))
+		}
+	}
+}
+
+// setComputed sets a cell calculated when flushed and adds it to the aggregations and sizes
+func (cw *Writer) setComputed(row *dataRow, i int, cell *CellData) {
+	col := cw.columns[i]
+	row.cells[i] = cell
+	for _, agg := range col.aggregations {
+		_ = agg.AddValue(cell.value)
+	}
+	col.ensureSize(cw, cell, col.style)
+}
@@ -0,0 +1,72 @@
+package columns
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvRenderer struct {
+	comma rune
+	w     *csv.Writer
+}
+
+// CSV creates a Renderer for comma separated values
+//
+// Values are written raw (without thousand-separators, prefix or suffix),
+// footers get the aggregation name in the first empty field (if any)
+func CSV() Renderer {
+	return &csvRenderer{comma: ','}
+}
+
+// TSV creates a Renderer for tab separated values (see CSV)
+func TSV() Renderer {
+	return &csvRenderer{comma: '\t'}
+}
+
+func (r *csvRenderer) write(record []string) error {
+	if err := r.w.Write(record); err != nil {
+		return err
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
+
+func (r *csvRenderer) Begin(w io.Writer, headers []string, align []Alignment) error {
+	r.w = csv.NewWriter(w)
+	r.w.Comma = r.comma
+
+	for _, hdr := range headers {
+		if hdr != "" {
+			return r.write(headers)
+		}
+	}
+	return nil
+}
+
+func (r *csvRenderer) record(values []interface{}) []string {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = rawText(v)
+	}
+	return record
+}
+
+func (r *csvRenderer) Row(w io.Writer, values []interface{}, texts []string) error {
+	return r.write(r.record(values))
+}
+
+func (r *csvRenderer) Cut(w io.Writer, lines int) error {
+	return nil
+}
+
+func (r *csvRenderer) Footer(w io.Writer, name string, values []interface{}, texts []string) error {
+	record := r.record(values)
+	if i := labelIndex(values); i >= 0 {
+		record[i] = name
+	}
+	return r.write(record)
+}
+
+func (r *csvRenderer) End(w io.Writer) error {
+	return nil
+}
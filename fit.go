@@ -0,0 +1,111 @@
+package columns
+
+import (
+	"os"
+	"strconv"
+)
+
+// default minimum width of a column shrunk to fit the terminal
+const defaultMinWidth = 4
+
+// Width sets the width the table must fit into (0 uses the terminal width when writing to a terminal)
+func (cw *Writer) Width(n int) {
+	cw.width = n
+}
+
+// Fit sets how column 'i' (1-based) is shrunk when the table is wider than the terminal (or Width)
+//
+// Columns with the lowest priority are shrunk first, but never below 'min' cells.
+// The values are truncated (or wrapped) using the mode set by MaxWidth.
+// Columns with numerical values are never shrunk
+func (cw *Writer) Fit(i, priority, min int) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].priority = priority
+		cw.columns[i].minWidth = min
+	}
+}
+
+// targetWidth returns the width the table must fit into (0 for unlimited)
+func (cw *Writer) targetWidth() int {
+	if cw.width > 0 {
+		return cw.width
+	}
+	if cw.terminal == nil {
+		return 0
+	}
+	if n := terminalWidth(cw.terminal.Fd()); n > 0 {
+		return n
+	}
+	n, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return n
+}
+
+// tableWidth returns the current width of a row
+func (cw *Writer) tableWidth() int {
+	total := 0
+	for _, spacer := range cw.spacers {
+		total += width(spacer)
+	}
+	for _, col := range cw.columns {
+		total += col.outerSize()
+	}
+	return total
+}
+
+// autoFit shrinks the columns until the table fits the target width (or no more columns can be shrunk)
+func (cw *Writer) autoFit() {
+	target := cw.targetWidth()
+	if target <= 0 {
+		return
+	}
+
+	done := make([]bool, cw.n)
+	for excess := cw.tableWidth() - target; excess > 0; excess = cw.tableWidth() - target {
+		var pick *column
+		index := -1
+		for i, col := range cw.columns {
+			if done[i] || col.sizeI > 0 || col.sizeF > 0 {
+				continue
+			}
+			if pick == nil || col.priority < pick.priority ||
+				(col.priority == pick.priority && col.outerSize() > pick.outerSize()) {
+				pick = col
+				index = i
+			}
+		}
+		if pick == nil {
+			return
+		}
+		done[index] = true
+		pick.shrink(pick.outerSize() - excess)
+	}
+}
+
+// shrink reduces the outer size of the column to 'size' (but not below the minimum width)
+func (col *column) shrink(size int) {
+	min := col.minWidth
+	if min <= 0 {
+		min = defaultMinWidth
+	}
+	if size < min {
+		size = min
+	}
+	if size >= col.outerSize() {
+		return
+	}
+
+	if col.sizeHeader > size {
+		col.sizeHeader = size
+	}
+	inner := size - col.sizePrefix - col.sizeSuffix
+	if inner < 1 {
+		inner = 1
+	}
+	if col.sizeValue > inner {
+		col.sizeValue = inner
+	}
+	if col.maxWidth <= 0 || col.maxWidth > inner {
+		col.maxWidth = inner
+	}
+}
@@ -0,0 +1,63 @@
+package columns
+
+// FooterLabel sets where the names of the aggregations are written;
+// in column 'i' (1-based) or, using 0, after the last column (the default)
+//
+// When the column has a value for the aggregation the name is written after the last column
+func (cw *Writer) FooterLabel(i int) {
+	if i >= 0 && i <= cw.n {
+		cw.labelColumn = i
+	}
+}
+
+// FooterName replaces the name written for an aggregation (e.g. to translate "Sum")
+func (cw *Writer) FooterName(name, label string) {
+	if cw.labels == nil {
+		cw.labels = make(map[string]string)
+	}
+	cw.labels[name] = label
+}
+
+// FooterRow adds a free-form row written after the aggregations (e.g. "Generated at ...")
+//
+// The values are sized like any other row, but are not included in sorting or aggregations
+func (cw *Writer) FooterRow(values ...interface{}) {
+	row := &dataRow{cells: make([]*CellData, cw.n)}
+	i := 0
+	for _, o := range values {
+		if i >= cw.n {
+			break
+		}
+		cell := toCell(o)
+		row.cells[i] = cell
+		if cell.span <= 1 {
+			cw.columns[i].ensureSize(cw, cell, cw.columns[i].style)
+		}
+		i += cell.columns()
+	}
+	cw.footerRows = append(cw.footerRows, row)
+}
+
+// label returns the name to write for an aggregation
+func (cw *Writer) label(name string) string {
+	if label, ok := cw.labels[name]; ok {
+		return label
+	}
+	return name
+}
+
+// labelCell returns a cell with the label (without any column prefix or suffix)
+func labelCell(label string) *CellData {
+	return Cell(label).Style(NewStyle().Prefix("").Suffix(""))
+}
+
+// ensureLabelSize makes room for the labels when written in a column
+func (cw *Writer) ensureLabelSize() {
+	if cw.labelColumn <= 0 {
+		return
+	}
+	col := cw.columns[cw.labelColumn-1]
+	for _, name := range cw.aggOrder {
+		col.ensureSize(cw, labelCell(cw.label(name)), col.style)
+	}
+}
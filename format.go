@@ -5,37 +5,78 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	space = " "
 )
 
-func (cw *Writer) format(cell *CellData) (txt string, size int, sizeI int, sizeF int) {
+// format formats the value of a cell, using the number format of the cell or 'style'
+func (cw *Writer) format(cell *CellData, style *Style) (txt string, size int, sizeI int, sizeF int) {
 	sizeI = 0
 	sizeF = 0
-	switch v := cell.value.(type) {
+	value := deref(cell.value)
+	nf := cell.number(style)
+	negative := cw.negativeOf(cell, style)
+	if nf.hasUnit() {
+		if n, ok := unitValue(value); ok {
+			return cw.formatFloat(n, nf, negative)
+		}
+	}
+
+	if txt, size, sizeI, sizeF, ok := cw.formatNumber(value, nf, negative); ok {
+		return txt, size, sizeI, sizeF
+	}
+
+	switch v := value.(type) {
+	case nil:
+		txt = ""
+
 	case string:
 		txt = v
-		size = len([]rune(txt))
+		size = textWidth(txt)
 
-	case int:
-		txt, size, sizeI, sizeF = cw.formatNumeric(strconv.FormatInt(abs(int64(v)), 10), v < 0)
+	case time.Time:
+		txt = cell.timeFormat(style).format(v)
+		size = width(txt)
 
-	case int64:
-		txt, size, sizeI, sizeF = cw.formatNumeric(strconv.FormatInt(abs(v), 10), v < 0)
-
-	case float64:
-		txt, size, sizeI, sizeF = cw.formatNumeric(fmt.Sprintf("%v", math.Abs(v)), v < 0)
+	case fmt.Stringer:
+		txt = v.String()
+		size = textWidth(txt)
 
 	default:
 		txt = fmt.Sprintf("%v", v)
-		size = len([]rune(txt))
+		size = width(txt)
 	}
 
 	return txt, size, sizeI, sizeF
 }
 
+func (cw *Writer) formatFloat(v float64, nf *numberFormat, negative Negative) (txt string, size int, sizeI int, sizeF int) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		txt = strconv.FormatFloat(v, 'f', -1, 64)
+		return txt, width(txt), 0, 0
+	}
+	digits, exp := formatFloat(math.Abs(v), nf)
+	return cw.formatNumeric(digits, exp, v < 0 && !isZero(digits), negative)
+}
+
+// spaces returns 'n' spaces (or nothing when 'n' is not positive)
+func spaces(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat(space, n)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func abs(v int64) int64 {
 	if v < 0 {
 		return -v
@@ -43,44 +84,65 @@ func abs(v int64) int64 {
 	return v
 }
 
-func (cw *Writer) formatNumeric(input string, neg bool) (txt string, size int, sizeI int, sizeF int) {
+// formatNumeric formats the digits of a number, an exponent is aligned after the decimals
+// (or after the integer part if there are no decimals)
+func (cw *Writer) formatNumeric(input string, exp string, neg bool, negative Negative) (txt string, size int, sizeI int, sizeF int) {
 	parts := strings.Split(input, ".")
 
-	var txtI, txtF string
-	txtI = separate(parts[0], -len(parts[0]), cw.ThousandSeparator)
+	var txtI, txtF, end string
+	txtI = separate(parts[0], cw.grouping, cw.ThousandSeparator)
 	if neg {
-		txtI = "- " + txtI
+		txtI = negate(txtI, negative)
+		end = trail(negative)
 	}
 	if len(parts) > 1 {
 		txtF = parts[1]
 	}
+	if txtF == "" {
+		txtI += exp
+	} else {
+		txtF += exp
+	}
 
-	sizeI = len([]rune(txtI))
-	sizeF = len([]rune(txtF))
+	sizeI = width(txtI)
+	sizeF = width(txtF) + width(end) // the end of a negative number is aligned with the decimals
 
 	txt = txtI
-	size = sizeI
-	if sizeF > 0 {
-		size += 1 + sizeF
+	size = sizeI + sizeF
+	if txtF != "" {
+		size++
 		txt += string(cw.DecimalSeparator) + txtF
 	}
-	return txt, size, sizeI, sizeF
+	return txt + end, size, sizeI, sizeF
 }
 
-func separate(txt string, i int, sep rune) string {
-	result := make([]rune, 0, 10)
-	lastWasSeparator := false
-	for _, ch := range txt {
-		lastWasSeparator = false
-		result = append(result, ch)
-		i++
-		if i%3 == 0 && sep != rune(0) {
-			lastWasSeparator = true
-			result = append(result, sep)
+// separate inserts 'sep' between the groups of digits, 'grouping' contains the sizes of the groups
+// from the right (the last size is repeated, 3 when empty)
+func separate(txt string, grouping []int, sep rune) string {
+	if sep == rune(0) {
+		return txt
+	}
+	if len(grouping) == 0 {
+		grouping = []int{3}
+	}
+
+	digits := []rune(txt)
+	var groups []string
+	end := len(digits)
+	for g := 0; end > 0; g++ {
+		size := grouping[len(grouping)-1]
+		if g < len(grouping) {
+			size = grouping[g]
+		}
+		if size <= 0 { // the rest in one group
+			break
 		}
+		start := maxInt(end-size, 0)
+		groups = append([]string{string(digits[start:end])}, groups...)
+		end = start
 	}
-	if lastWasSeparator {
-		result = result[:len(result)-1]
+	if end > 0 {
+		groups = append([]string{string(digits[:end])}, groups...)
 	}
-	return string(result)
+	return strings.Join(groups, string(sep))
 }
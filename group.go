@@ -0,0 +1,139 @@
+package columns
+
+import "strings"
+
+// GroupBy splits the rows into groups on the values of one or more columns (1-based)
+// and writes subtotals (using the footers of each column) after each group
+//
+// The rows are not sorted, call Sort on the same columns to get one group per value.
+// The subtotals are named after the aggregation and the group, e.g. "Sum (Europe)".
+// Only aggregations implementing Cloner (all aggregations of this package do) are included
+// in the subtotals, others are only written as grand totals.
+// Groups are ignored in streaming mode and by other output formats than the default
+func (cw *Writer) GroupBy(columns ...int) {
+	cw.groupBy = cw.groupBy[:0]
+	for _, c := range columns {
+		if c > 0 && c <= cw.n {
+			cw.groupBy = append(cw.groupBy, c-1)
+		}
+	}
+}
+
+// Cloner is implemented by aggregations that can create a new, empty, instance of themselves
+//
+// Only aggregations implementing Cloner are included in the subtotals of GroupBy
+type Cloner interface {
+	Clone() Aggregation
+}
+
+// grouping contains the groups found in the rows
+type grouping struct {
+	first     []bool                           // row is the first row of a group
+	subtotals map[int][]map[string]Aggregation // per last row of a group: aggregations per column
+	keys      map[int]string                   // per last row of a group: the group values
+}
+
+func (cw *Writer) groupKey(row *dataRow) []string {
+	key := make([]string, len(cw.groupBy))
+	for k, i := range cw.groupBy {
+		if c := row.cells[i]; !c.isEmpty() {
+			key[k], _, _, _ = cw.format(c, cw.columns[i].style)
+		}
+	}
+	return key
+}
+
+func sameKey(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newSubtotals creates empty copies of the aggregations for each column
+func (cw *Writer) newSubtotals() []map[string]Aggregation {
+	aggs := make([]map[string]Aggregation, cw.n)
+	for i, col := range cw.columns {
+		for name, agg := range col.aggregations {
+			c, ok := agg.(Cloner)
+			if !ok {
+				continue
+			}
+			if clone := c.Clone(); clone != nil {
+				if aggs[i] == nil {
+					aggs[i] = make(map[string]Aggregation)
+				}
+				aggs[i][name] = clone
+			}
+		}
+	}
+	return aggs
+}
+
+// makeGroups finds the groups and calculates their subtotals
+func (cw *Writer) makeGroups() *grouping {
+	g := &grouping{
+		first:     make([]bool, len(cw.data)),
+		subtotals: make(map[int][]map[string]Aggregation),
+		keys:      make(map[int]string),
+	}
+
+	var key []string
+	var aggs []map[string]Aggregation
+	for r, row := range cw.data {
+		rowKey := cw.groupKey(row)
+		if r == 0 || !sameKey(key, rowKey) {
+			g.first[r] = true
+			key = rowKey
+			aggs = cw.newSubtotals()
+		}
+		for i, c := range row.cells {
+			for _, agg := range aggs[i] {
+				if c != nil && c.span <= 1 {
+					_ = agg.AddValue(c.value)
+				}
+			}
+		}
+		if r == len(cw.data)-1 || !sameKey(key, cw.groupKey(cw.data[r+1])) {
+			g.subtotals[r] = aggs
+			g.keys[r] = strings.Join(key, " / ")
+		}
+	}
+	return g
+}
+
+// ensureSize makes room for the subtotals (and their labels) in the columns
+func (g *grouping) ensureSize(cw *Writer) {
+	for r, aggs := range g.subtotals {
+		for i, col := range cw.columns {
+			for _, agg := range aggs[i] {
+				col.ensureSize(cw, aggCell(agg), col.style)
+			}
+		}
+		if cw.labelColumn > 0 {
+			col := cw.columns[cw.labelColumn-1]
+			for _, name := range cw.aggOrder {
+				col.ensureSize(cw, labelCell(g.label(cw, r)(name)), col.style)
+			}
+		}
+	}
+}
+
+// label returns the names of the subtotals of the group ending at row 'r', including the values of the group
+func (g *grouping) label(cw *Writer, r int) func(name string) string {
+	return func(name string) string {
+		return cw.label(name) + " (" + g.keys[r] + ")"
+	}
+}
+
+// suppress returns the cells of the row with the group values removed (when they are repeated)
+func (cw *Writer) suppress(row *dataRow) *dataRow {
+	cells := make([]*CellData, len(row.cells))
+	copy(cells, row.cells)
+	for _, i := range cw.groupBy {
+		cells[i] = nil
+	}
+	return &dataRow{cells: cells, style: row.style}
+}
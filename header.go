@@ -0,0 +1,150 @@
+package columns
+
+import "strings"
+
+type headerGroup struct {
+	label string
+	first int // 0-based index of the first column
+	last  int // 0-based index of the last column
+}
+
+// HeaderStyle applies a style (only the color is used) to the header of column 'i' (1-based),
+// use 0 to style all headers (including the header groups)
+func (cw *Writer) HeaderStyle(i int, style *Style) {
+	if i == 0 {
+		cw.headerStyle = style
+		return
+	}
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].headerStyle = style
+	}
+}
+
+// HeaderAlign aligns the header of column 'i' (1-based) differently from its values
+func (cw *Writer) HeaderAlign(i int, align Alignment) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].headerAlign = align
+	}
+}
+
+// HeaderGroup adds a label above the headers of the columns 'first' to 'last' (1-based, inclusive)
+//
+// The columns are widened if the label doesn't fit
+func (cw *Writer) HeaderGroup(label string, first, last int) {
+	first--
+	last--
+	if first < 0 || last >= cw.n || first > last {
+		return
+	}
+	cw.groups = append(cw.groups, headerGroup{label: label, first: first, last: last})
+}
+
+// spanWidth returns the width of the columns 'first' to 'last' (0-based, inclusive) including the spacers between them
+func (cw *Writer) spanWidth(first, last int) int {
+	size := 0
+	for i := first; i <= last; i++ {
+		if i > first {
+			size += width(cw.spacers[i])
+		}
+		size += cw.columns[i].outerSize()
+	}
+	return size
+}
+
+// fitSpans widens the last column of header groups and spanning cells that doesn't fit their text
+func (cw *Writer) fitSpans() {
+	widen := func(first, last, size int) {
+		if missing := size - cw.spanWidth(first, last); missing > 0 {
+			col := cw.columns[last]
+			col.sizeHeader = col.outerSize() + missing
+		}
+	}
+
+	for _, grp := range cw.groups {
+		widen(grp.first, grp.last, width(grp.label))
+	}
+	for _, rows := range [][]*dataRow{cw.data, cw.footerRows} {
+		for _, row := range rows {
+			for i, c := range row.cells {
+				if c != nil && c.span > 1 {
+					widen(i, cw.spanLast(c, i), textWidth(cw.spanText(c)))
+				}
+			}
+		}
+	}
+}
+
+func (col *column) headerAlignment() Alignment {
+	if col.headerAlign != 0 {
+		return col.headerAlign
+	}
+	return col.align
+}
+
+func (cw *Writer) headerStyleOf(col *column) *Style {
+	if col.headerStyle != nil {
+		return col.headerStyle
+	}
+	return cw.headerStyle
+}
+
+// writeGroups writes the line with the header groups
+func (cw *Writer) writeGroups() {
+	if len(cw.groups) == 0 {
+		return
+	}
+
+	for i := 0; i < cw.n; i++ {
+		cw.print(cw.spacers[i])
+
+		var grp *headerGroup
+		for g := range cw.groups {
+			if cw.groups[g].first == i {
+				grp = &cw.groups[g]
+			}
+		}
+		if grp == nil {
+			cw.print(spaces(cw.columns[i].outerSize()))
+			continue
+		}
+
+		size := cw.spanWidth(grp.first, grp.last)
+		cw.print(cw.styled(cw.headerStyle, pad(truncate(grp.label, size, TruncateEnd, cw.Ellipsis), size, AlignMiddle, ' ')))
+		i = grp.last
+	}
+	cw.print(cw.spacers[cw.n])
+	cw.print("\n")
+}
+
+// writeHeaders writes the headers, headers containing newlines are written on multiple lines
+func (cw *Writer) writeHeaders() {
+	lines := make([][]string, cw.n)
+	count := 1
+	for i := range cw.columns {
+		if i < len(cw.headers) {
+			lines[i] = strings.Split(cw.headers[i], "\n")
+		}
+		if len(lines[i]) > count {
+			count = len(lines[i])
+		}
+	}
+
+	for line := 0; line < count; line++ {
+		for i, col := range cw.columns {
+			cw.print(cw.spacers[i])
+
+			txt := ""
+			if line < len(lines[i]) {
+				txt = lines[i][line]
+			}
+			if width(txt) > col.outerSize() {
+				txt = truncate(txt, col.outerSize(), TruncateEnd, cw.Ellipsis)
+			}
+			cw.print(cw.styled(cw.headerStyleOf(col), pad(txt, col.outerSize(), col.headerAlignment(), ' ')))
+		}
+		cw.print(cw.spacers[cw.n])
+		cw.print("\n")
+	}
+}
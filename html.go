@@ -0,0 +1,84 @@
+package columns
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+type htmlRenderer struct {
+	align   []string
+	footers int
+}
+
+// HTML creates a Renderer writing a <table> (without any surrounding document)
+func HTML() Renderer {
+	return &htmlRenderer{}
+}
+
+func (r *htmlRenderer) cells(tag string, texts []string) string {
+	var sb strings.Builder
+	sb.WriteString("  <tr>")
+	for i, txt := range texts {
+		fmt.Fprintf(&sb, "<%s style=\"text-align:%s\">%s</%s>", tag, r.align[i], html.EscapeString(txt), tag)
+	}
+	return sb.String()
+}
+
+func (r *htmlRenderer) Begin(w io.Writer, headers []string, align []Alignment) error {
+	r.align = make([]string, len(align))
+	for i, a := range align {
+		switch a {
+		case AlignLeft:
+			r.align[i] = "left"
+		case AlignMiddle:
+			r.align[i] = "center"
+		default:
+			r.align[i] = "right"
+		}
+	}
+
+	thead := ""
+	for _, hdr := range headers {
+		if hdr != "" {
+			thead = "<thead>\n" + r.cells("th", headers) + "</tr>\n</thead>\n"
+			break
+		}
+	}
+	_, err := io.WriteString(w, "<table>\n"+thead+"<tbody>\n")
+	return err
+}
+
+func (r *htmlRenderer) Row(w io.Writer, values []interface{}, texts []string) error {
+	_, err := io.WriteString(w, r.cells("td", texts)+"</tr>\n")
+	return err
+}
+
+func (r *htmlRenderer) Cut(w io.Writer, lines int) error {
+	_, err := fmt.Fprintf(w, "  <tr><td colspan=\"%d\">cut %d lines</td></tr>\n", len(r.align), lines)
+	return err
+}
+
+func (r *htmlRenderer) Footer(w io.Writer, name string, values []interface{}, texts []string) error {
+	start := ""
+	if r.footers == 0 {
+		start = "</tbody>\n<tfoot>\n"
+	}
+	r.footers++
+	if i := labelIndex(values); i >= 0 {
+		texts = append([]string(nil), texts...)
+		texts[i] = name
+	}
+	_, err := io.WriteString(w, start+r.cells("td", texts)+"</tr>\n")
+	return err
+}
+
+func (r *htmlRenderer) End(w io.Writer) error {
+	end := "</tbody>\n</table>\n"
+	if r.footers > 0 {
+		end = "</tfoot>\n</table>\n"
+	}
+	_, err := io.WriteString(w, end)
+	return err
+}
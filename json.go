@@ -0,0 +1,91 @@
+package columns
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type jsonRenderer struct {
+	keys    []string
+	rows    int
+	footers int
+}
+
+// JSON creates a Renderer writing a JSON object with the rows and footers
+//
+// Each row is an object using the headers as keys ("column N" when a header is missing),
+// values are written raw (without thousand-separators, prefix or suffix)
+//
+//	{"rows": [{"Planet": "Earth", "Radius": 1}], "footers": {"Sum": {"Radius": 1}}}
+func JSON() Renderer {
+	return &jsonRenderer{}
+}
+
+func jsonValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
+
+func (r *jsonRenderer) object(values []interface{}, skipEmpty bool) string {
+	var fields []string
+	for i, v := range values {
+		if v == nil && skipEmpty {
+			continue
+		}
+		fields = append(fields, jsonValue(r.keys[i])+": "+jsonValue(v))
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func (r *jsonRenderer) Begin(w io.Writer, headers []string, align []Alignment) error {
+	r.keys = make([]string, len(headers))
+	for i, hdr := range headers {
+		if hdr == "" {
+			hdr = fmt.Sprintf("column %d", i+1)
+		}
+		r.keys[i] = hdr
+	}
+	_, err := io.WriteString(w, "{\n\"rows\": [")
+	return err
+}
+
+func (r *jsonRenderer) Row(w io.Writer, values []interface{}, texts []string) error {
+	sep := ","
+	if r.rows == 0 {
+		sep = ""
+	}
+	r.rows++
+	_, err := io.WriteString(w, sep+"\n  "+r.object(values, false))
+	return err
+}
+
+func (r *jsonRenderer) Cut(w io.Writer, lines int) error {
+	return nil
+}
+
+func (r *jsonRenderer) Footer(w io.Writer, name string, values []interface{}, texts []string) error {
+	sep := ","
+	if r.footers == 0 {
+		sep = "\n],\n\"footers\": {"
+	}
+	r.footers++
+	if name == "" {
+		name = fmt.Sprintf("footer %d", r.footers)
+	}
+	_, err := io.WriteString(w, sep+"\n  "+jsonValue(name)+": "+r.object(values, true))
+	return err
+}
+
+func (r *jsonRenderer) End(w io.Writer) error {
+	end := "\n]\n}\n"
+	if r.footers > 0 {
+		end = "\n}\n}\n"
+	}
+	_, err := io.WriteString(w, end)
+	return err
+}
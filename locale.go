@@ -0,0 +1,30 @@
+package columns
+
+// Locale contains the conventions for writing numbers
+type Locale struct {
+	Thousand rune     // separator between groups of digits (0 for none)
+	Decimal  rune     // decimal separator
+	Grouping []int    // sizes of the digit-groups from the right, the last size is repeated (default 3)
+	Negative Negative // how negative numbers are written
+}
+
+// Predefined locales
+var (
+	LocaleDefault = Locale{Thousand: ' ', Decimal: '.', Grouping: []int{3}, Negative: NegativeSpaced}     // 1 234.5
+	LocaleEnglish = Locale{Thousand: ',', Decimal: '.', Grouping: []int{3}, Negative: NegativeMinus}      // 1,234.5
+	LocaleSwedish = Locale{Thousand: ' ', Decimal: ',', Grouping: []int{3}, Negative: NegativeMinus}      // 1 234,5
+	LocaleGerman  = Locale{Thousand: '.', Decimal: ',', Grouping: []int{3}, Negative: NegativeMinus}      // 1.234,5
+	LocaleSwiss   = Locale{Thousand: '\'', Decimal: '.', Grouping: []int{3}, Negative: NegativeMinus}     // 1'234.5
+	LocaleFrench  = Locale{Thousand: '\u202F', Decimal: ',', Grouping: []int{3}, Negative: NegativeMinus} // 1 234,5
+	LocaleIndian  = Locale{Thousand: ',', Decimal: '.', Grouping: []int{3, 2}, Negative: NegativeMinus}   // 12,34,567.5
+)
+
+// Locale sets the separators, grouping and negative numbers (for all values, including footers)
+//
+//	cw.Locale(columns.LocaleGerman)
+func (cw *Writer) Locale(locale Locale) {
+	cw.ThousandSeparator = locale.Thousand
+	cw.DecimalSeparator = locale.Decimal
+	cw.grouping = locale.Grouping
+	cw.negative = locale.Negative
+}
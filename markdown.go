@@ -0,0 +1,76 @@
+package columns
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+type markdown struct{}
+
+// Markdown creates a Renderer for (GitHub flavored) Markdown tables
+func Markdown() Renderer {
+	return &markdown{}
+}
+
+func (md *markdown) line(w io.Writer, cells []string) error {
+	_, err := io.WriteString(w, "| "+strings.Join(cells, " | ")+" |\n")
+	return err
+}
+
+func mdEscape(txt string) string {
+	txt = strings.ReplaceAll(txt, "|", "\\|")
+	return strings.ReplaceAll(txt, "\n", "<br>")
+}
+
+func (md *markdown) Begin(w io.Writer, headers []string, align []Alignment) error {
+	cells := make([]string, len(headers))
+	for i, hdr := range headers {
+		cells[i] = mdEscape(hdr)
+	}
+	if err := md.line(w, cells); err != nil {
+		return err
+	}
+
+	for i, a := range align {
+		switch a {
+		case AlignLeft:
+			cells[i] = ":---"
+		case AlignMiddle:
+			cells[i] = ":---:"
+		default:
+			cells[i] = "---:"
+		}
+	}
+	return md.line(w, cells)
+}
+
+func (md *markdown) Row(w io.Writer, values []interface{}, texts []string) error {
+	cells := make([]string, len(texts))
+	for i, txt := range texts {
+		cells[i] = mdEscape(txt)
+	}
+	return md.line(w, cells)
+}
+
+func (md *markdown) Cut(w io.Writer, lines int) error {
+	_, err := fmt.Fprintf(w, "| *cut %d lines* |\n", lines)
+	return err
+}
+
+func (md *markdown) Footer(w io.Writer, name string, values []interface{}, texts []string) error {
+	cells := make([]string, len(texts))
+	for i, txt := range texts {
+		if txt != "" {
+			cells[i] = "**" + mdEscape(txt) + "**"
+		}
+	}
+	if len(cells) > 0 && name != "" {
+		cells[0] = strings.TrimSpace(fmt.Sprintf("*%s* %s", name, cells[0]))
+	}
+	return md.line(w, cells)
+}
+
+func (md *markdown) End(w io.Writer) error {
+	return nil
+}
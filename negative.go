@@ -0,0 +1,90 @@
+package columns
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ninlil/ansi"
+)
+
+// Negative is how negative numbers are written
+type Negative int
+
+// Negative number styles
+const (
+	NegativeSpaced      Negative = iota + 1 // - 123 (the default)
+	NegativeMinus                           // -123
+	NegativeUnicode                         // −123 (using the unicode minus sign)
+	NegativeParentheses                     // (123) as in accounting
+	NegativeTrailing                        // 123-
+)
+
+// Negative sets how negative numbers are written (overriding the Locale of the Writer)
+func (s *Style) Negative(mode Negative) *Style {
+	s.negative = mode
+	return s
+}
+
+// NegativeColor colors negative numbers, e.g. ansi.Red
+func (s *Style) NegativeColor(color ansi.Style) *Style {
+	s.negColor = color
+	return s
+}
+
+// isNegative returns if 'v' is written as a negative number using 'nf'
+// (values rounded to zero are not negative)
+func isNegative(v interface{}, nf *numberFormat) bool {
+	n, ok := unitValue(v)
+	if !ok || n >= 0 || math.IsNaN(n) || math.IsInf(n, 0) { // infinity is written as text
+		return false
+	}
+	digits, _ := formatFloat(-n, nf)
+	return !isZero(digits)
+}
+
+// isZero returns if the digits of a number are all zeros
+func isZero(digits string) bool {
+	return strings.Trim(digits, "0.") == ""
+}
+
+// negativeOf returns how negative numbers are written in the cell
+func (cw *Writer) negativeOf(cell *CellData, style *Style) Negative {
+	if cell.style != nil && cell.style.negative != 0 {
+		return cell.style.negative
+	}
+	if style != nil && style.negative != 0 {
+		return style.negative
+	}
+	return cw.negative
+}
+
+// negate marks the integer part of a number as negative
+func negate(txtI string, mode Negative) string {
+	switch mode {
+	case NegativeMinus:
+		return "-" + txtI
+	case NegativeUnicode:
+		return "\u2212" + txtI
+	case NegativeParentheses:
+		return "(" + txtI
+	case NegativeTrailing:
+		return txtI
+	}
+	return "- " + txtI
+}
+
+// trail returns the end of a negative number, written right after the last digit
+func trail(mode Negative) string {
+	switch mode {
+	case NegativeParentheses:
+		return ")"
+	case NegativeTrailing:
+		return "-"
+	}
+	return ""
+}
+
+// suffixOf returns the complete suffix of a cell: the unit and the suffix of the style
+func (cw *Writer) suffixOf(cell *CellData, style *Style) string {
+	return cell.unit(style) + cell.suffix(style)
+}
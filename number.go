@@ -0,0 +1,115 @@
+package columns
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type numberMode int
+
+const (
+	numberFixed numberMode = iota + 1
+	numberMaxDecimals
+	numberSignificant
+	numberScientific
+	numberEngineering
+)
+
+type numberFormat struct {
+	mode   numberMode
+	digits int
+	unit   string
+}
+
+// defaultPrecision is the number of significant digits used for floats without a number format,
+// hiding rounding errors like 0.30000000000000004
+const defaultPrecision = 15
+
+// Fixed formats numerical values with exactly 'decimals' decimals (padding with trailing zeros)
+func (s *Style) Fixed(decimals int) *Style {
+	s.number = &numberFormat{mode: numberFixed, digits: maxInt(decimals, 0)}
+	return s
+}
+
+// MaxDecimals rounds numerical values to at most 'decimals' decimals (without trailing zeros)
+func (s *Style) MaxDecimals(decimals int) *Style {
+	s.number = &numberFormat{mode: numberMaxDecimals, digits: maxInt(decimals, 0)}
+	return s
+}
+
+// Significant rounds numerical values to 'digits' significant digits
+func (s *Style) Significant(digits int) *Style {
+	s.number = &numberFormat{mode: numberSignificant, digits: maxInt(digits, 1)}
+	return s
+}
+
+// Scientific formats numerical values in scientific notation with 'decimals' decimals (1.23e+06)
+func (s *Style) Scientific(decimals int) *Style {
+	s.number = &numberFormat{mode: numberScientific, digits: maxInt(decimals, 0)}
+	return s
+}
+
+// Engineering formats numerical values in engineering notation, with an exponent
+// that is a multiple of 3, and 'decimals' decimals (1.23e+06, 12.3e+03)
+func (s *Style) Engineering(decimals int) *Style {
+	s.number = &numberFormat{mode: numberEngineering, digits: maxInt(decimals, 0)}
+	return s
+}
+
+func (cell *CellData) number(style *Style) *numberFormat {
+	if cell.style != nil && cell.style.number != nil {
+		return cell.style.number
+	}
+	if style != nil {
+		return style.number
+	}
+	return nil
+}
+
+// formatFloat formats a positive value, returning the digits and the exponent (if any)
+func formatFloat(v float64, nf *numberFormat) (digits string, exp string) {
+	if nf == nil {
+		v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'g', defaultPrecision, 64), 64)
+		return strconv.FormatFloat(v, 'f', -1, 64), ""
+	}
+
+	switch nf.mode {
+	case numberFixed:
+		return strconv.FormatFloat(v, 'f', nf.digits, 64), ""
+
+	case numberMaxDecimals:
+		digits = strconv.FormatFloat(v, 'f', nf.digits, 64)
+		if strings.Contains(digits, ".") {
+			digits = strings.TrimRight(strings.TrimRight(digits, "0"), ".")
+		}
+		return digits, ""
+
+	case numberSignificant:
+		v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'g', nf.digits, 64), 64)
+		return strconv.FormatFloat(v, 'f', -1, 64), ""
+
+	case numberScientific:
+		txt := strconv.FormatFloat(v, 'e', nf.digits, 64)
+		i := strings.IndexByte(txt, 'e')
+		return txt[:i], txt[i:]
+
+	case numberBytes, numberDecimalBytes, numberSI, numberDuration:
+		v, _, decimals := nf.scale(v)
+		return strconv.FormatFloat(v, 'f', decimals, 64), ""
+
+	case numberEngineering:
+		e := 0
+		if v != 0 {
+			e = int(math.Floor(math.Log10(v)/3)) * 3
+		}
+		digits = strconv.FormatFloat(v/math.Pow10(e), 'f', nf.digits, 64)
+		if m, _ := strconv.ParseFloat(digits, 64); m >= 1000 { // rounded up to the next exponent
+			e += 3
+			digits = strconv.FormatFloat(v/math.Pow10(e), 'f', nf.digits, 64)
+		}
+		return digits, fmt.Sprintf("e%+03d", e)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64), ""
+}
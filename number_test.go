@@ -0,0 +1,185 @@
+package columns
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      float64
+		nf     *numberFormat
+		digits string
+		exp    string
+	}{
+		{"default", 1234.5, nil, "1234.5", ""},
+		{"default rounding error", 0.1 + 0.2, nil, "0.3", ""},
+		{"default large", 1e21, nil, "1000000000000000000000", ""},
+		{"default small", 0.000125, nil, "0.000125", ""},
+
+		{"fixed", 7, NewStyle().Fixed(2).number, "7.00", ""},
+		{"fixed rounds", 1234.5678, NewStyle().Fixed(2).number, "1234.57", ""},
+		{"fixed none", 2.5, NewStyle().Fixed(0).number, "2", ""},
+
+		{"max decimals", 1234.5678, NewStyle().MaxDecimals(2).number, "1234.57", ""},
+		{"max decimals trims", 7.1, NewStyle().MaxDecimals(3).number, "7.1", ""},
+		{"max decimals integer", 7.001, NewStyle().MaxDecimals(2).number, "7", ""},
+		{"max decimals keeps integer zeros", 100, NewStyle().MaxDecimals(2).number, "100", ""},
+
+		{"significant", 1234.5, NewStyle().Significant(3).number, "1230", ""},
+		{"significant small", 0.000123456, NewStyle().Significant(3).number, "0.000123", ""},
+		{"significant rounds up", 999.96, NewStyle().Significant(4).number, "1000", ""},
+		{"significant large", 1.5e21, NewStyle().Significant(2).number, "1500000000000000000000", ""},
+
+		{"scientific", 1234.5, NewStyle().Scientific(2).number, "1.23", "e+03"},
+		{"scientific small", 0.000123, NewStyle().Scientific(1).number, "1.2", "e-04"},
+		{"scientific zero", 0, NewStyle().Scientific(2).number, "0.00", "e+00"},
+
+		{"engineering", 1234.5, NewStyle().Engineering(1).number, "1.2", "e+03"},
+		{"engineering hundreds", 123456, NewStyle().Engineering(1).number, "123.5", "e+03"},
+		{"engineering small", 0.000123456, NewStyle().Engineering(1).number, "123.5", "e-06"},
+		{"engineering rollover", 999999, NewStyle().Engineering(1).number, "1.0", "e+06"},
+		{"engineering rollover small", 0.99999, NewStyle().Engineering(1).number, "1.0", "e+00"},
+		{"engineering below rollover", 999.94, NewStyle().Engineering(1).number, "999.9", "e+00"},
+		{"engineering zero", 0, NewStyle().Engineering(0).number, "0", "e+00"},
+
+		{"bytes", 512, NewStyle().Bytes(1).number, "512", ""},
+		{"bytes kib", 1536, NewStyle().Bytes(1).number, "1.5", ""},
+		{"bytes gib", 3.2 * 1024 * 1024 * 1024, NewStyle().Bytes(1).number, "3.2", ""},
+		{"bytes rollover", 1048575, NewStyle().Bytes(1).number, "1.0", ""},
+		{"decimal bytes", 1500, NewStyle().DecimalBytes(2).number, "1.50", ""},
+		{"si", 2.4e9, NewStyle().SI(1, "Hz").number, "2.4", ""},
+		{"si small", 0.0015, NewStyle().SI(2, "A").number, "1.50", ""},
+		{"si rollover", 999999, NewStyle().SI(1, "").number, "1.0", ""},
+		{"duration", 0.25, NewStyle().Duration(0).number, "250", ""},
+		{"duration minutes", 90, NewStyle().Duration(1).number, "1.5", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digits, exp := formatFloat(tt.v, tt.nf)
+			if digits != tt.digits || exp != tt.exp {
+				t.Errorf("formatFloat(%v) = %q, %q, want %q, %q", tt.v, digits, exp, tt.digits, tt.exp)
+			}
+		})
+	}
+}
+
+func TestUnitScale(t *testing.T) {
+	tests := []struct {
+		v    float64
+		nf   *numberFormat
+		unit string
+	}{
+		{512, NewStyle().Bytes(1).number, "B"},
+		{1536, NewStyle().Bytes(1).number, "KiB"},
+		{1048575, NewStyle().Bytes(1).number, "MiB"},
+		{1500, NewStyle().DecimalBytes(1).number, "kB"},
+		{2.4e9, NewStyle().SI(1, "Hz").number, "GHz"},
+		{0.0015, NewStyle().SI(1, "A").number, "mA"},
+		{440, NewStyle().SI(1, "Hz").number, "Hz"},
+		{12e-9, NewStyle().Duration(1).number, "ns"},
+		{0.0015, NewStyle().Duration(1).number, "ms"},
+		{90, NewStyle().Duration(1).number, "min"},
+		{7200, NewStyle().Duration(1).number, "h"},
+	}
+	for _, tt := range tests {
+		if _, unit, _ := tt.nf.scale(tt.v); unit != tt.unit {
+			t.Errorf("scale(%v) unit = %q, want %q", tt.v, unit, tt.unit)
+		}
+	}
+}
+
+func TestFormatNegative(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		style *Style
+		want  string
+	}{
+		{-1234.5, nil, "- 1 234.5"},
+		{-1234.5, NewStyle().Negative(NegativeMinus), "-1 234.5"},
+		{-1234.5, NewStyle().Negative(NegativeUnicode), "−1 234.5"},
+		{-1234.5, NewStyle().Negative(NegativeParentheses), "(1 234.5)"},
+		{-1234.5, NewStyle().Negative(NegativeTrailing), "1 234.5-"},
+		{-0.001, NewStyle().Fixed(2), "0.00"},
+		{-0.001, NewStyle().Fixed(2).Negative(NegativeParentheses), "0.00"},
+		{-0.004, NewStyle().MaxDecimals(2), "0"},
+		{-0.001, NewStyle().Engineering(1), "- 1.0e-03"},
+		{-0.1, NewStyle().Bytes(1), "0 B"},
+		{-2 * time.Second, NewStyle().Duration(0), "- 2 s"},
+		{-2 * time.Second, NewStyle().Duration(0).Negative(NegativeParentheses), "(2) s"},
+		{-5 * time.Second, NewStyle().Negative(NegativeParentheses), "-5s"}, // written as text
+		{-5 * time.Second, NewStyle().Negative(NegativeTrailing), "-5s"},
+		{-7, nil, "- 7"},
+	}
+	cw := New(io.Discard, "<")
+	for _, tt := range tests {
+		c := Cell(tt.v)
+		txt, _, _, _ := cw.format(c, tt.style)
+		if got := txt + cw.suffixOf(c, tt.style); got != tt.want {
+			t.Errorf("format(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestNegativeAlignment(t *testing.T) {
+	tests := []struct {
+		mode Negative
+		want string
+	}{
+		{NegativeParentheses, "(100)  \n   1.5 \n  (1.5)\n  12   \n"},
+		{NegativeTrailing, "100-  \n  1.5 \n  1.5-\n 12   \n"},
+		{NegativeMinus, "-100  \n   1.5\n  -1.5\n  12  \n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cw := New(&buf, ">")
+		cw.Style(1, NewStyle().Negative(tt.mode))
+		for _, v := range []interface{}{-100, 1.5, -1.5, 12} {
+			_ = cw.Write(v)
+		}
+		_ = cw.Flush()
+		if got := buf.String(); got != tt.want {
+			t.Errorf("mode %d:\n%s\nwant:\n%s", tt.mode, got, tt.want)
+		}
+	}
+}
+
+type ticket struct{ id int }
+
+func (t *ticket) String() string { return fmt.Sprintf("T#%d", t.id) }
+
+func TestFormatPointers(t *testing.T) {
+	seven := 7
+	half := 0.5
+	var nothing *int
+	timeout := 5 * time.Second
+	link, _ := url.Parse("https://example.com/path")
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{&seven, "7"},
+		{&half, "0.5"},
+		{nothing, ""},
+		{(*big.Int)(nil), ""},
+		{big.NewInt(42), "42"},
+		{&timeout, "5s"},
+		{errors.New("boom"), "boom"},       // Error with a pointer receiver
+		{&ticket{7}, "T#7"},                // String with a pointer receiver
+		{link, "https://example.com/path"}, // not printed as a struct
+		{&struct{ n int }{7}, "&{7}"},
+	}
+	cw := New(io.Discard, "<")
+	for _, tt := range tests {
+		if txt, _, _, _ := cw.format(Cell(tt.v), nil); txt != tt.want {
+			t.Errorf("format(%#v) = %q, want %q", tt.v, txt, tt.want)
+		}
+	}
+}
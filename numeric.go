@@ -0,0 +1,282 @@
+package columns
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// deref returns the number, time or text a pointer points to (nil for nil pointers),
+// big-numbers are always returned as pointers and other pointers are kept (e.g. a String-method with a pointer receiver)
+func deref(v interface{}) interface{} {
+	switch n := v.(type) {
+	case nil:
+		return nil
+	case *big.Int, *big.Float, *big.Rat:
+		if reflect.ValueOf(v).IsNil() { // a nil big-number is an empty cell
+			return nil
+		}
+		return v
+	case big.Int:
+		return &n
+	case big.Float:
+		return &n
+	case big.Rat:
+		return &n
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return v
+	}
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.CanInterface() {
+		return v
+	}
+	if rv.Kind() == reflect.Struct && rv.CanAddr() {
+		if p := rv.Addr().Interface(); isBig(p) {
+			return p
+		}
+	}
+
+	elem := rv.Interface()
+	if hasText(v) && !hasText(elem) { // String or Error with a pointer receiver
+		return v
+	}
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return elem
+	}
+	if _, ok := elem.(time.Time); ok {
+		return elem
+	}
+	return v
+}
+
+// hasText returns if the value is written using its own String or Error method
+func hasText(v interface{}) bool {
+	switch v.(type) {
+	case fmt.Stringer, error:
+		return true
+	}
+	return false
+}
+
+func isBig(v interface{}) bool {
+	switch v.(type) {
+	case *big.Int, *big.Float, *big.Rat:
+		return true
+	}
+	return false
+}
+
+// float32to64 converts a float32 without adding digits (0.1 stays 0.1)
+func float32to64(v float32) float64 {
+	n, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+	return n
+}
+
+// integer returns the digits of an integer value (of any width or named integer type)
+func integer(v interface{}) (digits string, neg bool, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := rv.Int()
+		if n < 0 {
+			return strconv.FormatUint(uint64(-n), 10), true, true // also correct for math.MinInt64
+		}
+		return strconv.FormatInt(n, 10), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), false, true
+	}
+	return "", false, false
+}
+
+// floating returns the value of a float (of any width or named float type)
+func floating(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32:
+		return float32to64(float32(rv.Float())), true
+	case reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// signed is an integer of any width, without loss
+type signed struct {
+	neg bool
+	abs uint64
+}
+
+// nativeInt returns the value of an integer (of any width or named integer type)
+func nativeInt(v interface{}) (signed, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := rv.Int(); n < 0 {
+			return signed{neg: true, abs: uint64(-n)}, true
+		}
+		return signed{abs: uint64(rv.Int())}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return signed{abs: rv.Uint()}, true
+	}
+	return signed{}, false
+}
+
+func (a signed) cmp(b signed) int {
+	switch {
+	case a.neg != b.neg && a.neg:
+		return -1
+	case a.neg != b.neg:
+		return 1
+	case a.abs == b.abs:
+		return 0
+	case (a.abs < b.abs) != a.neg:
+		return -1
+	}
+	return 1
+}
+
+// exact returns the exact value of integers and big-numbers (false for floats and other values)
+func exact(v interface{}) (*big.Rat, bool) {
+	if n, ok := nativeInt(v); ok {
+		r := new(big.Rat).SetInt(new(big.Int).SetUint64(n.abs))
+		if n.neg {
+			r.Neg(r)
+		}
+		return r, true
+	}
+	switch n := v.(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(n), true
+	case *big.Rat:
+		return n, true
+	case *big.Float:
+		if n.IsInf() {
+			return nil, false
+		}
+		r, _ := n.Rat(nil)
+		return r, true
+	case json.Number:
+		return new(big.Rat).SetString(string(n))
+	}
+	return nil, false
+}
+
+// compareExact compares two integers or big-numbers without rounding them to float64,
+// ok is false if any of them is a float (or not a number)
+func compareExact(a, b interface{}) (c int, ok bool) {
+	if x, ok := nativeInt(a); ok {
+		if y, ok := nativeInt(b); ok {
+			return x.cmp(y), true
+		}
+	}
+	x, ok1 := exact(a)
+	y, ok2 := exact(b)
+	if ok1 && ok2 {
+		return x.Cmp(y), true
+	}
+	return 0, false
+}
+
+// bigNum returns the value of a big-number or json.Number as a float64
+func bigNum(v interface{}) (float64, bool) {
+	switch n := deref(v).(type) { // nil for nil pointers
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f, true
+	case *big.Float:
+		f, _ := n.Float64()
+		return f, true
+	case *big.Rat:
+		f, _ := n.Float64()
+		return f, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// formatNumber formats numerical values of all types, ok is false for other values
+func (cw *Writer) formatNumber(v interface{}, nf *numberFormat, negative Negative) (txt string, size int, sizeI int, sizeF int, ok bool) {
+	switch v.(type) {
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+	case fmt.Stringer:
+		return "", 0, 0, 0, false // e.g. time.Duration and enums
+	}
+
+	var digits string
+	var neg bool
+	switch n := v.(type) {
+	case json.Number:
+		if _, err := n.Int64(); err == nil && nf == nil {
+			digits, neg = string(n), n[0] == '-'
+			if neg {
+				digits = digits[1:]
+			}
+		} else if f, err := n.Float64(); err == nil {
+			txt, size, sizeI, sizeF = cw.formatFloat(f, nf, negative)
+			return txt, size, sizeI, sizeF, true
+		} else {
+			return "", 0, 0, 0, false
+		}
+
+	case *big.Int:
+		if nf != nil {
+			f, _ := bigNum(n)
+			txt, size, sizeI, sizeF = cw.formatFloat(f, nf, negative)
+			return txt, size, sizeI, sizeF, true
+		}
+		digits, neg = new(big.Int).Abs(n).String(), n.Sign() < 0
+
+	case *big.Float:
+		if nf != nil || n.IsInf() {
+			f, _ := n.Float64()
+			txt, size, sizeI, sizeF = cw.formatFloat(f, nf, negative)
+			return txt, size, sizeI, sizeF, true
+		}
+		digits, neg = new(big.Float).Abs(n).Text('f', -1), n.Sign() < 0
+
+	case *big.Rat:
+		if nf != nil || !n.IsInt() {
+			f, _ := n.Float64()
+			txt, size, sizeI, sizeF = cw.formatFloat(f, nf, negative)
+			return txt, size, sizeI, sizeF, true
+		}
+		digits, neg = new(big.Int).Abs(n.Num()).String(), n.Sign() < 0
+
+	default:
+		if f, isFloat := floating(v); isFloat {
+			txt, size, sizeI, sizeF = cw.formatFloat(f, nf, negative)
+			return txt, size, sizeI, sizeF, true
+		}
+		if digits, neg, ok = integer(v); !ok {
+			return "", 0, 0, 0, false
+		}
+		if nf != nil {
+			f, _ := strconv.ParseFloat(digits, 64)
+			if neg {
+				f = -f
+			}
+			txt, size, sizeI, sizeF = cw.formatFloat(f, nf, negative)
+			return txt, size, sizeI, sizeF, true
+		}
+	}
+
+	txt, size, sizeI, sizeF = cw.formatNumeric(digits, "", neg, negative)
+	return txt, size, sizeI, sizeF, true
+}
@@ -0,0 +1,112 @@
+package columns
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Renderer outputs the table in another format than the default aligned text-columns
+//
+// Each row is given both as the raw values (nil when empty) and as the formatted
+// texts (with separators, prefix and suffix, but without any padding)
+type Renderer interface {
+	Begin(w io.Writer, headers []string, align []Alignment) error
+	Row(w io.Writer, values []interface{}, texts []string) error
+	Cut(w io.Writer, lines int) error
+	Footer(w io.Writer, name string, values []interface{}, texts []string) error
+	End(w io.Writer) error
+}
+
+// Output sets the renderer used by Flush (nil restores the default text-columns)
+func (cw *Writer) Output(r Renderer) {
+	cw.renderer = r
+}
+
+// output is the io.Writer given to the renderer, nothing is written after the first error
+type output struct {
+	cw *Writer
+}
+
+func (o output) Write(p []byte) (int, error) {
+	if o.cw.err != nil {
+		return 0, o.cw.err
+	}
+	n, err := o.cw.bufwr.Write(p)
+	o.cw.check(err)
+	return n, err
+}
+
+func (cw *Writer) renderBegin() {
+	headers := make([]string, cw.n)
+	copy(headers, cw.headers)
+	align := make([]Alignment, cw.n)
+	for i, col := range cw.columns {
+		align[i] = col.align
+	}
+	cw.check(cw.renderer.Begin(output{cw}, headers, align))
+}
+
+func (cw *Writer) renderCells(row []*CellData) (values []interface{}, texts []string) {
+	values = make([]interface{}, cw.n)
+	texts = make([]string, cw.n)
+	for i, col := range cw.columns {
+		if i >= len(row) || row[i].isEmpty() {
+			continue
+		}
+		values[i] = row[i].value
+		texts[i] = cw.cellText(row[i], col)
+	}
+	return values, texts
+}
+
+// cellText returns the formatted value with prefix and suffix, but without any alignment
+func (cw *Writer) cellText(c *CellData, col *column) string {
+	txt, _, _, _ := cw.format(c, col.style)
+	return c.prefix(col.style) + txt + cw.suffixOf(c, col.style)
+}
+
+// labelIndex returns the first empty column of a footer (-1 if there is none) to write the name in
+func labelIndex(values []interface{}) int {
+	for i, v := range values {
+		if v == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// rawText converts a value to text for machine-readable formats (no separators or styling)
+func rawText(v interface{}) string {
+	v = deref(v)
+	switch n := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return n
+	case float32:
+		return strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case float64:
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return ""
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case time.Time:
+		if n.IsZero() {
+			return ""
+		}
+		return n.Format(time.RFC3339)
+	case *big.Float:
+		return n.Text('f', -1)
+	case *big.Rat:
+		if n.IsInt() {
+			return n.Num().String()
+		}
+		f, _ := n.Float64()
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,51 @@
+package columns
+
+// RowData contains the values of a row together with a style for the entire row
+type RowData struct {
+	values []interface{}
+	style  *Style
+}
+
+// Row creates a row that can be styled as a whole, pass it as the only argument to Write
+//
+//	cw.Write(columns.Row("job-1", "failed").Style(red))
+func Row(values ...interface{}) *RowData {
+	return &RowData{
+		values: values,
+	}
+}
+
+// Style applies a style to the entire row (only the color is used)
+func (r *RowData) Style(style *Style) *RowData {
+	r.style = style
+	return r
+}
+
+// RowFunc returns the style for a row depending on its values (or nil for no style)
+type RowFunc func(values []interface{}) *Style
+
+// RowStyle sets a function styling rows depending on their values (for rows not styled using Row)
+func (cw *Writer) RowStyle(fn RowFunc) {
+	cw.rowFunc = fn
+}
+
+// Zebra applies 'style' to every other row (typically a background color) to make rows easier to follow
+func (cw *Writer) Zebra(style *Style) {
+	cw.zebra = style
+}
+
+// dataRow is a row as stored in the Writer
+type dataRow struct {
+	cells []*CellData
+	style *Style
+}
+
+func (r *dataRow) values() []interface{} {
+	values := make([]interface{}, len(r.cells))
+	for i, c := range r.cells {
+		if c != nil {
+			values[i] = c.value
+		}
+	}
+	return values
+}
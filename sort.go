@@ -1,9 +1,11 @@
 package columns
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Sort the lines base on one (or more) columns
@@ -12,16 +14,59 @@ import (
 // Sort(2,3) will sort on column 2 and, if necessary, column 3
 //
 // In a column with mixed datatypes (strings & numerical), numerical values are grouped 1st, strings 2nd, nil are always last
-// regardless of sorting ascending or descending
+// regardless of sorting ascending or descending.
+// The rows written so far are sorted directly (and stable, so sorting on one column after another combines the orders).
+// Sorting on a column calculated when flushed (ComputeTotals or Transform) is done when flushed,
+// together with any later sorts. Use SortMode or SortFunc to order a column differently
 func (cw *Writer) Sort(columns ...int) {
+	if cw.stream {
+		return
+	}
+	if len(cw.sortBy) > 0 || cw.lateSort(columns) {
+		cw.sortBy = append(cw.sortBy, append([]int(nil), columns...))
+		return
+	}
+	cw.sortRows(columns)
+}
+
+// sortColumn returns the 0-based column of a sort key (-1 when invalid)
+func (cw *Writer) sortColumn(key int) int {
+	i := int(abs(int64(key))) - 1
+	if i < 0 || i >= cw.n {
+		return -1
+	}
+	return i
+}
+
+// lateSort returns if any of the columns is calculated when flushed
+func (cw *Writer) lateSort(columns []int) bool {
+	for _, c := range columns {
+		if i := cw.sortColumn(c); i >= 0 && cw.columns[i].lateComputed() {
+			return true
+		}
+	}
+	return false
+}
+
+// transformSort returns if any of the columns is a transformed column
+func (cw *Writer) transformSort(columns []int) bool {
+	for _, c := range columns {
+		if i := cw.sortColumn(c); i >= 0 && cw.columns[i].transform != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// sortRows sorts the rows on one or more columns (as given to Sort)
+func (cw *Writer) sortRows(columns []int) {
 	var sorter = func(i, j int) bool {
 		for _, c := range columns {
 			var asc = c > 0
-			var colIndex = int(abs(int64(c))) - 1
-			if colIndex < len(cw.columns) {
-				a := cw.data[i][colIndex]
-				b := cw.data[j][colIndex]
-				switch compare(a, b, asc) {
+			if colIndex := cw.sortColumn(c); colIndex >= 0 {
+				a := cw.data[i].cells[colIndex]
+				b := cw.data[j].cells[colIndex]
+				switch compare(a, b, asc, cw.columns[colIndex]) {
 				case compareSwap:
 					return false
 				case compareKeep:
@@ -34,6 +79,29 @@ func (cw *Writer) Sort(columns ...int) {
 	sort.SliceStable(cw.data, sorter)
 }
 
+// lateSorts calculates the columns depending on all rows and does the sorts waiting for them
+//
+// Transforms are calculated on the order before the first sort using a transformed column
+func (cw *Writer) lateSorts() {
+	if cw.stream {
+		return
+	}
+	cw.computeTotals()
+
+	transformed := false
+	for _, columns := range cw.sortBy {
+		if !transformed && cw.transformSort(columns) {
+			cw.transformRows()
+			transformed = true
+		}
+		cw.sortRows(columns)
+	}
+	if !transformed {
+		cw.transformRows()
+	}
+	cw.sortBy = nil
+}
+
 type swap int
 
 const (
@@ -43,9 +111,10 @@ const (
 )
 
 func compareValue(a, b *CellData, asc bool) int {
-	switch x := a.value.(type) {
+	va, vb := deref(a.value), deref(b.value)
+	switch x := va.(type) {
 	case string:
-		switch y := b.value.(type) {
+		switch y := vb.(type) {
 		case string:
 			return strings.Compare(x, y)
 		default:
@@ -56,7 +125,19 @@ func compareValue(a, b *CellData, asc bool) int {
 		}
 	}
 
-	switch b.value.(type) {
+	if x, ok := va.(time.Time); ok {
+		if y, ok := vb.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	}
+
+	switch vb.(type) {
 	case string:
 		if asc {
 			return -1 // swap
@@ -64,8 +145,12 @@ func compareValue(a, b *CellData, asc bool) int {
 		return 1
 	}
 
-	n, ok1 := getNum(a.value)
-	m, ok2 := getNum(b.value)
+	if c, ok := compareExact(va, vb); ok { // large integers differ in digits float64 can't hold
+		return c
+	}
+
+	n, ok1 := getNum(va)
+	m, ok2 := getNum(vb)
 	if ok1 && ok2 {
 		diff := n - m
 		if math.Abs(diff) < 1e-9 {
@@ -76,13 +161,17 @@ func compareValue(a, b *CellData, asc bool) int {
 		}
 		return -1
 	}
+	if !ok1 && !ok2 { // e.g. fmt.Stringer
+		return strings.Compare(fmt.Sprint(va), fmt.Sprint(vb))
+	}
 	if !ok2 {
 		return 1
 	}
 	return 0
 }
 
-func compare(a, b *CellData, asc bool) swap {
+// compare compares two cells, using the custom order of the column (if any) for non-empty values
+func compare(a, b *CellData, asc bool, col *column) swap {
 	if a.isEmpty() && b.isEmpty() {
 		return compareKeep // both empty -> dont swap
 	}
@@ -93,7 +182,18 @@ func compare(a, b *CellData, asc bool) swap {
 		return compareKeep
 	}
 
-	comp := compareValue(a, b, asc)
+	var comp int
+	va, vb := deref(a.value), deref(b.value)
+	x, ok1 := va.(string)
+	y, ok2 := vb.(string)
+	switch {
+	case col.compare != nil:
+		comp = col.compare(va, vb)
+	case col.compareText != nil && ok1 && ok2:
+		comp = col.compareText(x, y)
+	default: // numbers before strings in both directions
+		comp = compareValue(a, b, asc)
+	}
 	if comp == 0 {
 		return compareEqual
 	}
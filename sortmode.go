@@ -0,0 +1,211 @@
+package columns
+
+import "strings"
+
+// SortMode is how strings in a column are ordered by Sort
+type SortMode int
+
+// Sort modes
+const (
+	SortDefault         SortMode = iota // byte-wise (strings.Compare)
+	SortNatural                         // numbers within the text are compared numerically: file2 < file10
+	SortCaseInsensitive                 // ignoring case: apple < Banana
+	SortSemver                          // semantic versions: v1.9.0 < v1.10.0 < v1.10.1-rc.1 < v1.10.1 (other texts last)
+)
+
+// CompareFunc compares two (non-empty) values, returning a negative number if 'a' is less than 'b',
+// 0 if they are equal and a positive number if 'a' is larger than 'b'
+type CompareFunc func(a, b interface{}) int
+
+// Collator compares strings using the rules of a language, e.g. *collate.Collator from golang.org/x/text/collate
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+// SortMode sets how strings in column 'i' (1-based) are ordered, other values are ordered as usual
+func (cw *Writer) SortMode(i int, mode SortMode) {
+	var cmp func(a, b string) int
+	switch mode {
+	case SortNatural:
+		cmp = naturalCompare
+	case SortCaseInsensitive:
+		cmp = foldCompare
+	case SortSemver:
+		cmp = semverCompare
+	}
+	cw.sortStrings(i, cmp)
+}
+
+// SortCollate orders the strings in column 'i' (1-based) using a collator (locale aware ordering)
+//
+//	cw.SortCollate(1, collate.New(language.Swedish))
+func (cw *Writer) SortCollate(i int, collator Collator) {
+	cw.sortStrings(i, collator.CompareString)
+}
+
+// SortFunc orders column 'i' (1-based) using 'fn', empty values are still ordered last
+func (cw *Writer) SortFunc(i int, fn CompareFunc) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].compare = fn
+		cw.columns[i].compareText = nil
+	}
+}
+
+// sortStrings orders the strings of column 'i' using 'cmp' (nil for the default order)
+func (cw *Writer) sortStrings(i int, cmp func(a, b string) int) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].compare = nil
+		cw.columns[i].compareText = cmp
+	}
+}
+
+func foldCompare(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// chunks splits a string into runs of digits and non-digits
+func chunks(s string) []string {
+	var result []string
+	start := 0
+	digits := false
+	for i, ch := range s {
+		if i > start && isDigit(ch) != digits {
+			result = append(result, s[start:i])
+			start = i
+		}
+		digits = isDigit(ch)
+	}
+	if start < len(s) {
+		result = append(result, s[start:])
+	}
+	return result
+}
+
+// compareDigits compares two runs of ASCII digits numerically (without limits on the size)
+func compareDigits(a, b string) int {
+	x := strings.TrimLeft(a, "0")
+	y := strings.TrimLeft(b, "0")
+	if len(x) != len(y) {
+		return len(x) - len(y)
+	}
+	return strings.Compare(x, y)
+}
+
+func isDigits(s string) bool {
+	for _, ch := range s {
+		if !isDigit(ch) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func naturalCompare(a, b string) int {
+	x, y := chunks(a), chunks(b)
+	zeros := 0 // equal numbers with fewer leading zeros first, unless the rest differs: x7a > x007
+	for i := 0; i < len(x) && i < len(y); i++ {
+		var c int
+		if isDigits(x[i]) && isDigits(y[i]) {
+			c = compareDigits(x[i], y[i])
+			if c == 0 && zeros == 0 {
+				zeros = len(x[i]) - len(y[i])
+			}
+		} else {
+			c = strings.Compare(x[i], y[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	if len(x) != len(y) {
+		return len(x) - len(y)
+	}
+	return zeros
+}
+
+type semver struct {
+	core       []string
+	prerelease []string
+}
+
+// parseSemver parses "1.2.3", "v1.2" or "1.2.3-rc.1+build" (the build is ignored)
+func parseSemver(s string) (semver, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var v semver
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.prerelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	v.core = strings.Split(s, ".")
+	for _, part := range v.core {
+		if !isDigits(part) {
+			return v, false
+		}
+	}
+	return v, true
+}
+
+func semverCompare(a, b string) int {
+	x, ok1 := parseSemver(a)
+	y, ok2 := parseSemver(b)
+	switch { // other texts are ordered (naturally) after the versions
+	case !ok1 && !ok2:
+		return naturalCompare(a, b)
+	case !ok1:
+		return 1
+	case !ok2:
+		return -1
+	}
+
+	for i := 0; i < len(x.core) || i < len(y.core); i++ {
+		p, q := "0", "0" // missing parts are 0: 1.2 == 1.2.0
+		if i < len(x.core) {
+			p = x.core[i]
+		}
+		if i < len(y.core) {
+			q = y.core[i]
+		}
+		if c := compareDigits(p, q); c != 0 {
+			return c
+		}
+	}
+
+	switch { // a release is larger than its pre-releases
+	case len(x.prerelease) == 0 && len(y.prerelease) == 0:
+		return 0
+	case len(x.prerelease) == 0:
+		return 1
+	case len(y.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(x.prerelease) && i < len(y.prerelease); i++ {
+		p, q := x.prerelease[i], y.prerelease[i]
+		var c int
+		switch {
+		case isDigits(p) && isDigits(q):
+			c = compareDigits(p, q)
+		case isDigits(p):
+			c = -1 // numeric identifiers are lower than alphanumeric
+		case isDigits(q):
+			c = 1
+		default:
+			c = strings.Compare(p, q)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return len(x.prerelease) - len(y.prerelease)
+}
@@ -0,0 +1,88 @@
+package columns
+
+import "testing"
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file2", "file2", 0},
+		{"file", "file2", -1},
+		{"2", "10", -1},
+		{"a1b2", "a1b10", -1},
+
+		{"file02", "file2", 1}, // same value, fewer leading zeros first
+		{"file002", "file02", 1},
+		{"file010", "file9", 1},
+		{"0", "00", -1},
+		{"x007", "x7a", -1},
+
+		{"123456789012345678901234567890", "123456789012345678901234567891", -1}, // beyond uint64
+		{"v99999999999999999999", "v100000000000000000000", -1},
+		{"id18446744073709551616", "id18446744073709551615", 1},
+
+		{"File2", "file10", -1}, // case is byte-wise, upper case first
+		{"file2", "File10", 1},
+		{"B", "a", -1},
+		{"ABC10", "ABC9", 1},
+	}
+	for _, tt := range tests {
+		if got := sign(naturalCompare(tt.a, tt.b)); got != tt.want {
+			t.Errorf("naturalCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.9.0", "1.10.0", -1},
+		{"v1.10.0", "1.10.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"2", "1.99.99", 1},
+		{"1.01.0", "1.1.0", 0}, // same value
+		{"1.01.0", "1.1.1", -1},
+
+		{"1.0.0-alpha", "1.0.0", -1}, // pre-releases before the release
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1}, // numeric before alphanumeric
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1}, // numeric identifiers compared numerically
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0-rc.1", 0},
+		{"1.0.1-alpha", "1.0.0", 1},
+
+		{"1.0.0+build.1", "1.0.0+build.2", 0}, // build metadata is ignored
+		{"1.0.0-rc.1+20240101", "1.0.0-rc.1", 0},
+		{"1.0.0+exp.sha.5114f85", "1.0.0-rc.1", 1},
+
+		{"latest", "1.0.0", 1}, // other texts last
+		{"1.0.0", "latest", -1},
+		{"1.x", "2.0.0", 1},
+		{"", "0.0.0", 1},
+		{"1..0", "1.0.0", 1},
+		{"dev10", "dev9", 1}, // both invalid, natural order
+		{"main", "main", 0},
+	}
+	for _, tt := range tests {
+		if got := sign(semverCompare(tt.a, tt.b)); got != tt.want {
+			t.Errorf("semverCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package columns
 
 import (
+	"time"
+
 	"github.com/ninlil/ansi"
 )
 
@@ -8,10 +10,13 @@ const colorFunc ansi.Style = -1
 
 // ColorFunc should return a ansi.Style value to set a style/color depending on data-value
 //
-// Please note: all numerical values are converted to float64 before called
+// Please note: all numerical values (except time.Duration) are converted to float64 before called
 type ColorFunc func(v interface{}) (color ansi.Style, ok bool)
 
 func getValueForColorFunc(v interface{}) interface{} {
+	if _, ok := v.(time.Duration); ok {
+		return v
+	}
 	n, ok := getNum(v)
 	if ok {
 		return n
@@ -26,6 +31,11 @@ type Style struct {
 
 	prefix *string
 	suffix *string
+	number *numberFormat
+	time   *timeFormat
+
+	negative Negative
+	negColor ansi.Style
 }
 
 // NewStyle creates a new style
@@ -58,39 +68,66 @@ func (s *Style) Suffix(text string) *Style {
 	return s
 }
 
-func (c *CellData) getStyle(col *column) *Style {
-	if c != nil && c.style != nil {
-		return c.style
+// colorOf returns the color of the style for the value (false if there is no color),
+// 'negative' is true when the value is written as a negative number
+func (s *Style) colorOf(v interface{}, negative bool) (ansi.Style, bool) {
+	if s == nil {
+		return ansi.Default, false
+	}
+	if s.negColor != ansi.Default && negative {
+		return s.negColor, true
 	}
-	return col.style
+	if s.color == ansi.Default {
+		return ansi.Default, false
+	}
+	if s.color == colorFunc {
+		return s.colorFn(getValueForColorFunc(v))
+	}
+	return s.color, true
 }
 
-func (c *CellData) beginStyle(col *column) string {
-	var style = c.getStyle(col)
-	if style == nil || style.color == ansi.Default {
-		return ""
+// beginStyle combines the colors of the cell (or column) with any row styles
+//
+// The cell style replaces the column style, row styles are layered on top of the
+// column style but beneath the cell style
+func (c *CellData) beginStyle(col *column, rowStyles []*Style) string {
+	var value interface{}
+	var cellStyle *Style
+	negative := false
+	if c != nil {
+		value = c.value
+		cellStyle = c.style
+		negative = isNegative(value, c.number(col.style))
 	}
 
-	var color ansi.Style = ansi.White
-	var ok bool
+	layers := make([]*Style, 0, len(rowStyles)+2)
+	if cellStyle == nil {
+		layers = append(layers, col.style)
+	}
+	layers = append(layers, rowStyles...)
+	layers = append(layers, cellStyle)
 
-	if style.color == colorFunc {
-		color, ok = style.colorFn(getValueForColorFunc(c.value))
-		if !ok {
-			return ""
+	var colors []ansi.Style
+	for _, style := range layers {
+		if color, ok := style.colorOf(value, negative); ok {
+			colors = append(colors, color)
 		}
-	} else {
-		color = style.color
+	}
+	if len(colors) == 0 {
+		return ""
 	}
 
-	return color.String()
+	return ansi.NewStyle(colors...).String()
 }
 
-func (c *CellData) endStyle(col *column) string {
-	var style = c.getStyle(col)
-	if style == nil || style.color == ansi.Default {
-		return ""
+// styled wraps 'txt' in the color of the style (when colors are used)
+func (cw *Writer) styled(style *Style, txt string) string {
+	if !cw.useColor {
+		return txt
 	}
-
-	return ansi.Default.String()
+	color, ok := style.colorOf(txt, false)
+	if !ok {
+		return txt
+	}
+	return color.String() + txt + ansi.Default.String()
 }
@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package columns
+
+import "os"
+
+// isTerminal returns if 'f' is a terminal, only files that are char devices are detected on this platform
+func isTerminal(f fileDescriptor) bool {
+	file, ok := f.(*os.File)
+	if !ok || file == nil {
+		return false
+	}
+	fileInfo, err := file.Stat()
+	return err == nil && (fileInfo.Mode()&os.ModeCharDevice) != 0
+}
+
+// terminalWidth is not supported on this platform, the COLUMNS environment variable is used instead
+func terminalWidth(fd uintptr) int {
+	return 0
+}
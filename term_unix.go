@@ -0,0 +1,30 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package columns
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// isTerminal returns if 'f' is a terminal (it has a window size)
+func isTerminal(f fileDescriptor) bool {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return errno == 0
+}
+
+// terminalWidth returns the number of columns of the terminal 'fd' (0 if unknown)
+func terminalWidth(fd uintptr) int {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.cols)
+}
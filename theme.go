@@ -0,0 +1,126 @@
+package columns
+
+import "strings"
+
+// Rule is a horizontal line in a Theme (no line is drawn when Fill is 0)
+type Rule struct {
+	Left     rune
+	Fill     rune
+	Junction rune
+	Right    rune
+}
+
+// Theme describes the borders around and between columns
+type Theme struct {
+	Vertical rune // border between columns (0 for none)
+	Top      Rule // above the headers
+	Header   Rule // between the headers and the values
+	Footer   Rule // between the values and the footers
+	Bottom   Rule // below the footers
+}
+
+// Predefined themes
+var (
+	ThemeASCII = Theme{
+		Vertical: '|',
+		Top:      Rule{'+', '-', '+', '+'},
+		Header:   Rule{'+', '-', '+', '+'},
+		Footer:   Rule{'+', '-', '+', '+'},
+		Bottom:   Rule{'+', '-', '+', '+'},
+	}
+	ThemeLight = Theme{
+		Vertical: '│',
+		Top:      Rule{'┌', '─', '┬', '┐'},
+		Header:   Rule{'├', '─', '┼', '┤'},
+		Footer:   Rule{'├', '─', '┼', '┤'},
+		Bottom:   Rule{'└', '─', '┴', '┘'},
+	}
+	ThemeHeavy = Theme{
+		Vertical: '┃',
+		Top:      Rule{'┏', '━', '┳', '┓'},
+		Header:   Rule{'┣', '━', '╋', '┫'},
+		Footer:   Rule{'┣', '━', '╋', '┫'},
+		Bottom:   Rule{'┗', '━', '┻', '┛'},
+	}
+	ThemeDouble = Theme{
+		Vertical: '║',
+		Top:      Rule{'╔', '═', '╦', '╗'},
+		Header:   Rule{'╠', '═', '╬', '╣'},
+		Footer:   Rule{'╠', '═', '╬', '╣'},
+		Bottom:   Rule{'╚', '═', '╩', '╝'},
+	}
+	ThemeRounded = Theme{
+		Vertical: '│',
+		Top:      Rule{'╭', '─', '┬', '╮'},
+		Header:   Rule{'├', '─', '┼', '┤'},
+		Footer:   Rule{'├', '─', '┼', '┤'},
+		Bottom:   Rule{'╰', '─', '┴', '╯'},
+	}
+	ThemeMinimal = Theme{
+		Header: Rule{' ', '─', ' ', ' '},
+		Footer: Rule{' ', '─', ' ', ' '},
+	}
+	ThemeMarkdown = Theme{
+		Vertical: '|',
+		Header:   Rule{'|', '-', '|', '|'},
+	}
+)
+
+// Theme draws the borders of the table using 'theme' (replaces the spacers from the format given to New)
+func (cw *Writer) Theme(theme Theme) {
+	cw.theme = &theme
+
+	for i := range cw.spacers {
+		switch {
+		case theme.Vertical == 0 && (i == 0 || i == cw.n):
+			cw.spacers[i] = ""
+		case theme.Vertical == 0:
+			cw.spacers[i] = "  "
+		case i == 0:
+			cw.spacers[i] = string(theme.Vertical) + space
+		case i == cw.n:
+			cw.spacers[i] = space + string(theme.Vertical)
+		default:
+			cw.spacers[i] = space + string(theme.Vertical) + space
+		}
+	}
+}
+
+// writeRule draws a horizontal line following the spacers and the column sizes
+//
+// When 'spanned' there are no junctions between columns belonging to the same header group
+func (cw *Writer) writeRule(rule Rule, spanned bool) {
+	if rule.Fill == 0 {
+		return
+	}
+
+	inGroup := func(i int) bool {
+		for _, grp := range cw.groups {
+			if spanned && i > grp.first && i <= grp.last {
+				return true
+			}
+		}
+		return false
+	}
+
+	var sb strings.Builder
+	for i, spacer := range cw.spacers {
+		for _, ch := range spacer {
+			switch {
+			case ch != cw.theme.Vertical && cw.theme.Vertical != 0, inGroup(i):
+				sb.WriteRune(rule.Fill)
+			case i == 0:
+				sb.WriteRune(rule.Left)
+			case i == cw.n:
+				sb.WriteRune(rule.Right)
+			default:
+				sb.WriteRune(rule.Junction)
+			}
+		}
+		if i < cw.n {
+			sb.WriteString(strings.Repeat(string(rule.Fill), cw.columns[i].outerSize()))
+		}
+	}
+	sb.WriteString("\n")
+	cw.print(sb.String())
+}
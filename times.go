@@ -0,0 +1,121 @@
+package columns
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeLayout is the layout of time.Time values without a TimeLayout
+const DefaultTimeLayout = "2006-01-02 15:04:05"
+
+type timeFormat struct {
+	layout   string
+	relative bool
+	location *time.Location
+}
+
+func (s *Style) times() *timeFormat {
+	if s.time == nil {
+		s.time = &timeFormat{}
+	}
+	return s.time
+}
+
+// TimeLayout formats time.Time values using 'layout' (as in time.Format)
+func (s *Style) TimeLayout(layout string) *Style {
+	s.times().layout = layout
+	return s
+}
+
+// Relative writes time.Time values relative to now, e.g. "3m ago" or "in 2h"
+func (s *Style) Relative() *Style {
+	s.times().relative = true
+	return s
+}
+
+// Location converts time.Time values to a timezone before formatting, e.g. time.UTC or time.Local
+func (s *Style) Location(loc *time.Location) *Style {
+	s.times().location = loc
+	return s
+}
+
+func (cell *CellData) timeFormat(style *Style) *timeFormat {
+	if cell.style != nil && cell.style.time != nil {
+		return cell.style.time
+	}
+	if style != nil && style.time != nil {
+		return style.time
+	}
+	return &timeFormat{}
+}
+
+// isEmpty returns if the value is nil, a nil pointer or the zero time
+func isEmpty(v interface{}) bool {
+	v = deref(v)
+	if t, ok := v.(time.Time); ok {
+		return t.IsZero()
+	}
+	return v == nil
+}
+
+// isBlank returns if the value is empty or an empty string
+func isBlank(v interface{}) bool {
+	return isEmpty(v) || deref(v) == ""
+}
+
+// format formats a time, the zero time is written as empty
+func (tf *timeFormat) format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if tf.relative {
+		return relative(t, time.Now())
+	}
+	if tf.location != nil {
+		t = t.In(tf.location)
+	}
+	if tf.layout != "" {
+		return t.Format(tf.layout)
+	}
+	return t.Format(DefaultTimeLayout)
+}
+
+// relative returns the time from 'now' to 't' in the largest suitable unit
+func relative(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var txt string
+	switch {
+	case d < time.Second:
+		return "now"
+	case d < time.Minute:
+		txt = fmt.Sprintf("%ds", d/time.Second)
+	case d < time.Hour:
+		txt = fmt.Sprintf("%dm", d/time.Minute)
+	case d < 24*time.Hour:
+		txt = fmt.Sprintf("%dh", d/time.Hour)
+	case d < 365*24*time.Hour:
+		txt = fmt.Sprintf("%dd", d/(24*time.Hour))
+	default:
+		txt = fmt.Sprintf("%dy", d/(365*24*time.Hour))
+	}
+
+	if future {
+		return "in " + txt
+	}
+	return txt + " ago"
+}
+
+// Earliest creates an 'Aggregation' with the earliest time (or smallest value, see Min)
+func Earliest() Aggregation {
+	return &aggMinMax{name: "Earliest"}
+}
+
+// Latest creates an 'Aggregation' with the latest time (or largest value, see Max)
+func Latest() Aggregation {
+	return &aggMinMax{name: "Latest", max: true}
+}
@@ -0,0 +1,111 @@
+package columns
+
+import "sort"
+
+// Transform is a calculation over the rows of a column
+type Transform int
+
+// Transforms
+const (
+	PercentOfTotal Transform = iota // the percentage of the total of the column
+	RunningTotal                    // the sum of the values so far
+	RunningAverage                  // the average of the values so far
+	Rank                            // the rank of the value (1 for the largest, equal values get the same rank)
+)
+
+type transform struct {
+	src  int // 0-based source column
+	mode Transform
+	prec int
+}
+
+// Transform makes column 'i' (1-based) calculated from the values in column 'src' (1-based)
+// using 'mode', rounded to 'prec' decimals
+//
+// The values are calculated when flushed, after sorting the rows, and are not available in streaming mode.
+// No value is given to Write for a transformed column
+//
+//	cw.Transform(3, 2, columns.PercentOfTotal, 1)
+func (cw *Writer) Transform(i, src int, mode Transform, prec int) {
+	i--
+	src--
+	if i >= 0 && i < cw.n && src >= 0 && src < cw.n && i != src {
+		cw.columns[i].transform = &transform{src: src, mode: mode, prec: prec}
+	}
+}
+
+// transformRows calculates the transformed columns
+func (cw *Writer) transformRows() {
+	if cw.stream {
+		return
+	}
+	for i, col := range cw.columns {
+		if col.transform != nil {
+			cw.transformColumn(i, col.transform)
+		}
+	}
+}
+
+func (cw *Writer) transformColumn(i int, t *transform) {
+	type value struct {
+		row *dataRow
+		n   float64
+	}
+	var values []value
+	var total float64
+	for _, row := range cw.data {
+		if row.covered()[i] || row.cells[i] != nil {
+			continue
+		}
+		if c := row.cells[t.src]; c != nil && c.span <= 1 {
+			if n, ok := getNum(c.value); ok {
+				values = append(values, value{row: row, n: n})
+				total += n
+			}
+		}
+	}
+
+	results := make([]float64, len(values))
+	switch t.mode {
+	case PercentOfTotal:
+		for k, v := range values {
+			if total != 0 {
+				results[k] = v.n / total * 100
+			}
+		}
+
+	case RunningTotal, RunningAverage:
+		var sum float64
+		for k, v := range values {
+			sum += v.n
+			results[k] = sum
+			if t.mode == RunningAverage {
+				results[k] = sum / float64(k+1)
+			}
+		}
+
+	case Rank:
+		order := make([]int, len(values))
+		for k := range order {
+			order[k] = k
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return values[order[a]].n > values[order[b]].n
+		})
+		for k, o := range order {
+			if k > 0 && values[o].n == values[order[k-1]].n {
+				results[o] = results[order[k-1]]
+			} else {
+				results[o] = float64(k + 1)
+			}
+		}
+	}
+
+	for k, v := range values {
+		if t.mode == Rank {
+			cw.setComputed(v.row, i, Cell(int(results[k])))
+		} else {
+			cw.setComputed(v.row, i, Cell(round(results[k], t.prec)))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package columns
+
+import "strings"
+
+// Truncate decides which part of a text to remove when it is wider than the column allows
+type Truncate int
+
+// Truncation modes
+const (
+	TruncateEnd    Truncate = iota // "abcdef" -> "abc…"
+	TruncateMiddle                 // "abcdef" -> "ab…f"
+	TruncateStart                  // "abcdef" -> "…def"
+	Wrap                           // "abc def" -> "abc" + "def" on a continuation line
+)
+
+// MaxWidth limits the width of the values in column 'i' (1-based)
+//
+// Longer texts are truncated (marked with the Writer's Ellipsis) or, using Wrap, continued on
+// the next line. Numerical values are never truncated; they are replaced with the Overflow character instead
+func (cw *Writer) MaxWidth(i, size int, mode Truncate) {
+	i--
+	if i >= 0 && i < cw.n {
+		cw.columns[i].maxWidth = size
+		cw.columns[i].truncate = mode
+	}
+}
+
+// truncate shortens 'txt' to at most 'size' cells, including the 'ellipsis'
+func truncate(txt string, size int, mode Truncate, ellipsis string) string {
+	if width(txt) <= size {
+		return txt
+	}
+
+	ellipsisSize := width(ellipsis)
+	if ellipsisSize >= size {
+		ellipsis = ""
+		ellipsisSize = 0
+	}
+	size -= ellipsisSize
+
+	runes := []rune(txt)
+	widths := runeWidths(txt)
+
+	// take runes from the start (or end) while they fit
+	take := func(size int, fromEnd bool) string {
+		n, used := 0, 0
+		for n < len(runes) {
+			i := n
+			if fromEnd {
+				i = len(runes) - 1 - n
+			}
+			if used+widths[i] > size {
+				break
+			}
+			used += widths[i]
+			n++
+		}
+		if fromEnd {
+			return string(runes[len(runes)-n:])
+		}
+		return string(runes[:n])
+	}
+
+	switch mode {
+	case TruncateStart:
+		return ellipsis + take(size, true)
+	case TruncateMiddle:
+		left := take(size-size/2, false)
+		return left + ellipsis + take(size-width(left), true) // the end gets what a wide character left over
+	}
+	return take(size, false) + ellipsis
+}
+
+// overflow returns the marker shown instead of a numerical value that doesn't fit
+func (cw *Writer) overflow(size int) string {
+	return strings.Repeat(string(cw.Overflow), size)
+}
+
+// wrap splits 'txt' into lines of at most 'size' cells, breaking between words when possible
+func wrap(txt string, size int) []string {
+	var lines []string
+	var line []rune
+	lineSize := 0
+
+	flush := func() {
+		lines = append(lines, strings.TrimRight(string(line), space))
+		line = line[:0]
+		lineSize = 0
+	}
+
+	for _, word := range strings.SplitAfter(txt, space) {
+		wordSize := width(strings.TrimRight(word, space))
+		if lineSize > 0 && lineSize+wordSize > size {
+			flush()
+		}
+
+		// words longer than a line are split where the line is full
+		runes := []rune(word)
+		widths := runeWidths(word)
+		for i, r := range runes {
+			if lineSize > 0 && lineSize+widths[i] > size && r != ' ' {
+				flush()
+			}
+			if lineSize == 0 && r == ' ' {
+				continue
+			}
+			line = append(line, r)
+			lineSize += widths[i]
+		}
+	}
+	if lineSize > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
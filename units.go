@@ -0,0 +1,132 @@
+package columns
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	numberBytes numberMode = iota + 100
+	numberDecimalBytes
+	numberSI
+	numberDuration
+)
+
+var (
+	binaryUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	decimalUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	siPrefixes   = []string{"p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E"} // siPrefixes[4] is 10^0
+)
+
+// Bytes formats numerical values as binary byte sizes (512 B, 1.5 KiB, 3.2 GiB) with 'decimals' decimals
+//
+// The unit is written as a suffix (before any suffix of the style), the value is still used for sorting and aggregations
+func (s *Style) Bytes(decimals int) *Style {
+	s.number = &numberFormat{mode: numberBytes, digits: maxInt(decimals, 0)}
+	return s
+}
+
+// DecimalBytes formats numerical values as decimal byte sizes (512 B, 1.5 kB, 3.2 GB) with 'decimals' decimals
+func (s *Style) DecimalBytes(decimals int) *Style {
+	s.number = &numberFormat{mode: numberDecimalBytes, digits: maxInt(decimals, 0)}
+	return s
+}
+
+// SI formats numerical values using SI-prefixes (p, n, µ, m, k, M, G, T, P, E) followed by 'unit' (e.g. "Hz")
+// with 'decimals' decimals
+func (s *Style) SI(decimals int, unit string) *Style {
+	s.number = &numberFormat{mode: numberSI, digits: maxInt(decimals, 0), unit: unit}
+	return s
+}
+
+// Duration formats durations using the most suitable unit (ns, µs, ms, s, min, h) with 'decimals' decimals
+//
+// Values can be time.Duration or numerical values in seconds
+func (s *Style) Duration(decimals int) *Style {
+	s.number = &numberFormat{mode: numberDuration, digits: maxInt(decimals, 0)}
+	return s
+}
+
+func (nf *numberFormat) hasUnit() bool {
+	return nf != nil && nf.mode >= numberBytes
+}
+
+// unitValue returns the value to scale to a unit (durations in seconds)
+func unitValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case bool, string:
+		return 0, false
+	case time.Duration:
+		return n.Seconds(), true
+	}
+	return getNum(v)
+}
+
+// scale returns the (positive) value scaled to a unit, together with the unit and the number of decimals to use
+func (nf *numberFormat) scale(v float64) (scaled float64, unit string, decimals int) {
+	v = math.Abs(v)
+	switch nf.mode {
+	case numberBytes, numberDecimalBytes:
+		units, base := binaryUnits, 1024.0
+		if nf.mode == numberDecimalBytes {
+			units, base = decimalUnits, 1000
+		}
+		i := 0
+		for i < len(units)-1 && (v >= base || (i > 0 && round(v, nf.digits) >= base)) {
+			v /= base
+			i++
+		}
+		if i == 0 {
+			return v, units[0], 0 // whole bytes
+		}
+		return v, units[i], nf.digits
+
+	case numberSI:
+		i := 4
+		if v != 0 {
+			for i > 0 && v < 1 {
+				v *= 1000
+				i--
+			}
+			for i < len(siPrefixes)-1 && round(v, nf.digits) >= 1000 {
+				v /= 1000
+				i++
+			}
+		}
+		return v, siPrefixes[i] + nf.unit, nf.digits
+
+	case numberDuration:
+		switch {
+		case v == 0:
+			return 0, "s", nf.digits
+		case v < 1e-6:
+			return v * 1e9, "ns", nf.digits
+		case v < 1e-3:
+			return v * 1e6, "µs", nf.digits
+		case v < 1:
+			return v * 1e3, "ms", nf.digits
+		case v < 60:
+			return v, "s", nf.digits
+		case v < 3600:
+			return v / 60, "min", nf.digits
+		}
+		return v / 3600, "h", nf.digits
+	}
+	return v, "", nf.digits
+}
+
+// unit returns the unit of the value when formatted (including a leading space)
+func (cell *CellData) unit(style *Style) string {
+	nf := cell.number(style)
+	if !nf.hasUnit() {
+		return ""
+	}
+	v, ok := unitValue(cell.value)
+	if !ok {
+		return ""
+	}
+	if _, unit, _ := nf.scale(v); unit != "" {
+		return " " + unit
+	}
+	return ""
+}
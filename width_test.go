@@ -0,0 +1,111 @@
+package columns
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		txt  string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "Earth", 5},
+		{"latin", "Göteborg", 8},
+		{"cjk", "日本語", 6},
+		{"hangul", "한국어", 6},
+		{"mixed scripts", "abc日本def", 10},
+		{"fullwidth", "ＡＢ", 4},
+		{"emoji", "🚀", 2},
+		{"emoji and text", "go 🚀!", 6},
+		{"skin tone", "👍🏽", 2},
+		{"zwj family", "👨‍👩‍👧", 2},
+		{"zwj with skin tones", "👩🏽‍💻", 2},
+		{"flag", "🇸🇪", 2},
+		{"two flags", "🇸🇪🇳🇴", 4},
+		{"odd regional indicators", "🇸🇪🇳", 4},
+		{"variation selector", "❤️", 2},
+		{"combining acute", "é", 1},
+		{"combining marks", "äö", 2},
+		{"cjk with combining", "日́本", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := width(tt.txt); got != tt.want {
+				t.Errorf("width(%q) = %d, want %d", tt.txt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	if got := textWidth("ab\n日本語\nc"); got != 6 {
+		t.Errorf("textWidth = %d, want 6", got)
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		txt   string
+		size  int
+		align Alignment
+		want  string
+	}{
+		{"日本", 6, AlignLeft, "日本  "},
+		{"日本", 6, AlignRight, "  日本"},
+		{"日本", 7, AlignMiddle, "  日本 "},
+		{"🇸🇪", 4, AlignRight, "  🇸🇪"},
+		{"👍🏽", 3, AlignLeft, "👍🏽 "},
+		{"é", 3, AlignRight, "  é"},
+		{"日本語", 4, AlignLeft, "日本語"}, // too wide, never cut
+	}
+	for _, tt := range tests {
+		if got := pad(tt.txt, tt.size, tt.align, ' '); got != tt.want {
+			t.Errorf("pad(%q, %d, %c) = %q, want %q", tt.txt, tt.size, tt.align, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		txt  string
+		size int
+		mode Truncate
+		want string
+	}{
+		{"Earth", 5, TruncateEnd, "Earth"},
+		{"Jupiter", 5, TruncateEnd, "Jupi…"},
+		{"日本語テキスト", 6, TruncateEnd, "日本…"},   // a wide character is never split
+		{"日本語テキスト", 5, TruncateEnd, "日本…"},   // 2+2+1
+		{"日本語テキスト", 4, TruncateEnd, "日…"},    // "日本" + ellipsis would be 5
+		{"日本語テキスト", 5, TruncateStart, "…スト"}, // from the end
+		{"日本語テキスト", 7, TruncateMiddle, "日…スト"},
+		{"a👍🏽b", 3, TruncateEnd, "a…"}, // the skin tone stays with its emoji
+		{"👍🏽👍🏽", 3, TruncateEnd, "👍🏽…"},
+		{"🇸🇪🇳🇴🇩🇰", 5, TruncateEnd, "🇸🇪🇳🇴…"}, // flags are not split
+		{"👨‍👩‍👧 family", 4, TruncateEnd, "👨‍👩‍👧 …"},
+		{"ééé", 2, TruncateEnd, "é…"}, // combining marks stay with their letter
+		{"日本語", 1, TruncateEnd, ""},       // no room for the ellipsis either
+	}
+	for _, tt := range tests {
+		got := truncate(tt.txt, tt.size, tt.mode, "…")
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d, %d) = %q, want %q", tt.txt, tt.size, tt.mode, got, tt.want)
+		}
+		if w := width(got); w > tt.size {
+			t.Errorf("truncate(%q, %d, %d) is %d wide", tt.txt, tt.size, tt.mode, w)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap("日本語のテキスト", 5)
+	want := []string{"日本", "語の", "テキ", "スト"}
+	if len(lines) != len(want) {
+		t.Fatalf("wrap = %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("wrap line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
@@ -7,32 +7,41 @@ import (
 )
 
 // Flush writes the completed columns to the output
-func (cw *Writer) Flush() {
+// (in streaming mode only the rows not yet written and the footers are printed)
+//
+// The first error from writing is returned
+func (cw *Writer) Flush() error {
+	if cw.err != nil {
+		return cw.err
+	}
 
-	if len(cw.aggOrder) > 0 {
-		for _, col := range cw.columns {
-			for _, agg := range col.aggregations {
-				col.ensureSize(cw, Cell(agg.Result()), col.style)
-			}
-		}
+	cw.lateSorts()
+
+	var groups *grouping
+	if len(cw.groupBy) > 0 && !cw.stream && cw.renderer == nil {
+		groups = cw.makeGroups()
 	}
 
-	for _, c := range cw.columns {
-		if c.sizeI > 0 || c.sizeF > 0 {
-			size := c.sizeI + c.sizeDot + c.sizeF
-			if c.sizeValue < size {
-				c.sizeValue = size
+	if !cw.started || cw.streamMode == StreamGrow {
+		if len(cw.aggOrder) > 0 {
+			for _, col := range cw.columns {
+				for _, agg := range col.aggregations {
+					col.ensureSize(cw, aggCell(agg), col.style)
+				}
 			}
 		}
+		if groups != nil {
+			groups.ensureSize(cw)
+		}
+		cw.ensureLabelSize()
+		cw.fitSizes()
 	}
 
-	cw.dump()
-
-	cw.bufwr = bufio.NewWriter(cw.writer)
-
-	sep := cw.flushHeaders()
+	if !cw.started {
+		cw.begin()
+	}
 
-	if cw.head < 0 {
+	if cw.head < 0 || cw.stream {
 		cw.head = len(cw.data)
 	}
 	cw.tail = len(cw.data) - cw.tail
@@ -40,68 +49,244 @@ func (cw *Writer) Flush() {
 	cutmsg := false
 	for i, row := range cw.data {
 		if i < cw.head || i >= cw.tail {
-			cw.writeCells(row, "\n")
+			if groups != nil && cw.SuppressRepeats && !groups.first[i] && (i-1 < cw.head || i-1 >= cw.tail) {
+				row = cw.suppress(row)
+			}
+			cw.writeRow(row)
+			if groups != nil && groups.subtotals[i] != nil {
+				cw.writeAggregations(groups.subtotals[i], groups.label(cw, i))
+			}
 		} else {
 			if !cutmsg {
-				_, _ = cw.bufwr.WriteString(fmt.Sprintf("--- cut %d lines ---\n", cw.tail-cw.head))
+				cw.writeCut(cw.tail - cw.head)
 				cutmsg = true
 			}
 		}
 	}
 
-	cw.flushAggregations(sep)
+	cw.flushFooters()
+
+	if cw.renderer != nil {
+		cw.check(cw.renderer.End(output{cw}))
+	} else if cw.theme != nil {
+		cw.writeRule(cw.theme.Bottom, false)
+	}
+
+	cw.flushOutput()
+	return cw.err
+}
+
+// Err returns the first error that occurred when writing to the output
+func (cw *Writer) Err() error {
+	return cw.err
+}
+
+// print writes 'txt' to the output unless a previous write has failed
+func (cw *Writer) print(txt string) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = cw.bufwr.WriteString(txt)
+}
+
+// check keeps the first error
+func (cw *Writer) check(err error) {
+	if cw.err == nil {
+		cw.err = err
+	}
+}
+
+func (cw *Writer) flushOutput() {
+	if cw.err != nil {
+		return
+	}
+	cw.err = cw.bufwr.Flush()
+}
+
+func (cw *Writer) fitSizes() {
+	for _, c := range cw.columns {
+		c.fit()
+	}
+}
+
+// begin prepares the output and writes the headers
+func (cw *Writer) begin() {
+	cw.dump()
+
+	cw.bufwr = bufio.NewWriter(cw.writer)
+	if cw.renderer != nil {
+		cw.renderBegin()
+	} else {
+		cw.fitSpans()
+		cw.autoFit()
+		cw.flushHeaders()
+	}
+	cw.started = true
+}
+
+func (cw *Writer) writeRow(row *dataRow) {
+	if cw.renderer != nil {
+		values, texts := cw.renderCells(row.cells)
+		cw.check(cw.renderer.Row(output{cw}, values, texts))
+		return
+	}
+
+	var zebra *Style
+	cw.printed++
+	if cw.printed%2 == 0 {
+		zebra = cw.zebra
+	}
+	cw.writeCells(row.cells, "", zebra, row.style)
+}
 
-	_ = cw.bufwr.Flush()
+func (cw *Writer) writeCut(lines int) {
+	if cw.renderer != nil {
+		cw.check(cw.renderer.Cut(output{cw}, lines))
+		return
+	}
+	cw.print(fmt.Sprintf("--- cut %d lines ---\n", lines))
 }
 
-func (cw *Writer) flushHeaders() []string {
-	var sep []string
+func (cw *Writer) flushHeaders() {
+	if cw.theme != nil {
+		cw.writeRule(cw.theme.Top, true)
+	}
+	cw.writeGroups()
 	if len(cw.headers) > 0 {
-		cw.writeStrings(cw.headers, "\n")
+		cw.writeHeaders()
 
-		if cw.HeaderSeparator {
-			sep = make([]string, cw.n)
-			for i, col := range cw.columns {
-				sep[i] = strings.Repeat("-", col.outerSize())
-			}
+		if cw.theme != nil {
+			cw.writeRule(cw.theme.Header, false)
+			return
+		}
+
+		if sep := cw.headerSeparator(); len(sep) > 0 {
 			cw.writeStrings(sep, "\n")
 		}
 	}
+}
+
+// headerSeparator returns the separator-line (if any) using the current column sizes
+func (cw *Writer) headerSeparator() []string {
+	if len(cw.headers) == 0 || !cw.HeaderSeparator {
+		return nil
+	}
+	sep := make([]string, cw.n)
+	for i, col := range cw.columns {
+		sep[i] = strings.Repeat("-", col.outerSize())
+	}
 	return sep
 }
 
-func (cw *Writer) flushAggregations(sep []string) {
-	if len(cw.aggOrder) <= 0 {
+func (cw *Writer) flushFooters() {
+	if len(cw.aggOrder) <= 0 && len(cw.footerRows) <= 0 {
 		return
 	}
 
-	if len(sep) > 0 {
-		cw.writeStrings(sep, "\n")
+	if cw.renderer == nil {
+		if cw.theme != nil {
+			cw.writeRule(cw.theme.Footer, false)
+		} else if sep := cw.headerSeparator(); len(sep) > 0 {
+			cw.writeStrings(sep, "\n")
+		}
+	}
+	aggs := make([]map[string]Aggregation, cw.n)
+	for i, col := range cw.columns {
+		aggs[i] = col.aggregations
+	}
+	cw.writeAggregations(aggs, cw.label)
+
+	for _, row := range cw.footerRows {
+		if cw.renderer != nil {
+			values, texts := cw.renderCells(row.cells)
+			cw.check(cw.renderer.Footer(output{cw}, "", values, texts))
+			continue
+		}
+		cw.writeCells(row.cells, "")
 	}
+}
+
+// writeAggregations writes one line per aggregation, 'aggs' contains the aggregations of each column
+// and 'label' returns the name to write for each aggregation
+func (cw *Writer) writeAggregations(aggs []map[string]Aggregation, label func(name string) string) {
 	for _, aggName := range cw.aggOrder {
 		aggline := make([]*CellData, cw.n)
-		for i, col := range cw.columns {
-			if agg, ok := col.aggregations[aggName]; ok {
-				aggline[i] = Cell(agg.Result())
+		found := false
+		for i := range cw.columns {
+			if agg, ok := aggs[i][aggName]; ok {
+				aggline[i] = aggCell(agg)
+				found = true
 			}
 		}
-		cw.writeCells(aggline, " ", aggName, "\n")
+		if !found {
+			continue
+		}
+		label := label(aggName)
+		if cw.renderer != nil {
+			values, texts := cw.renderCells(aggline)
+			cw.check(cw.renderer.Footer(output{cw}, label, values, texts))
+			continue
+		}
+		if i := cw.labelColumn - 1; i >= 0 && aggline[i] == nil {
+			aggline[i] = labelCell(label)
+			cw.writeCells(aggline, "")
+			continue
+		}
+		cw.writeCells(aggline, " "+label)
 	}
 }
 
-func (cw *Writer) writeCells(data []*CellData, suffix ...string) {
+// writeCells writes a row, using multiple lines if any cell contains newlines or is wrapped
+//
+// The 'trailer' is written after the first line, 'rowStyles' are combined with the
+// cell (or column) styles; later styles have precedence
+func (cw *Writer) writeCells(data []*CellData, trailer string, rowStyles ...*Style) {
+	cell := func(i int) *CellData {
+		if i < len(data) {
+			return data[i]
+		}
+		return nil
+	}
+
+	lines := make([][]string, cw.n)
+	count := 1
 	for i := 0; i < cw.n; i++ {
-		col := cw.columns[i]
+		if c := cell(i); c != nil && c.span > 1 {
+			last := cw.spanLast(c, i)
+			lines[i] = cw.spanLines(c, cw.columns[i], cw.spanWidth(i, last))
+			i = last
+		} else {
+			lines[i] = cw.cellLines(c, cw.columns[i])
+		}
+		if len(lines[i]) > count {
+			count = len(lines[i])
+		}
+	}
 
-		if len(cw.spacers[i]) > 0 {
-			_, _ = cw.bufwr.WriteString(cw.spacers[i])
+	for line := 0; line < count; line++ {
+		for i := 0; i < cw.n; i++ {
+			if len(cw.spacers[i]) > 0 {
+				cw.print(cw.spacers[i])
+			}
+			txt := ""
+			if line < len(lines[i]) {
+				txt = lines[i][line]
+			}
+			c := cell(i)
+			if c != nil && c.span > 1 {
+				last := cw.spanLast(c, i)
+				cw.writeSpan(c, cw.columns[i], txt, cw.spanWidth(i, last), rowStyles)
+				i = last
+				continue
+			}
+			cw.writeCell(c, cw.columns[i], txt, line == 0, rowStyles)
 		}
-		if i < len(data) {
-			cw.writeCell(data[i], col)
+		cw.print(cw.spacers[cw.n])
+		if line == 0 {
+			cw.print(trailer)
 		}
+		cw.print("\n")
 	}
-	_, _ = cw.bufwr.WriteString(cw.spacers[cw.n])
-	_, _ = cw.bufwr.WriteString(strings.Join(suffix, ""))
 }
 
 func (cw *Writer) writeStrings(data []string, suffix ...string) {
@@ -110,18 +295,22 @@ func (cw *Writer) writeStrings(data []string, suffix ...string) {
 		col := cw.columns[i]
 
 		if len(cw.spacers[i]) > 0 {
-			_, _ = cw.bufwr.WriteString(cw.spacers[i])
+			cw.print(cw.spacers[i])
 		}
 		if i < len(data) {
-			_, _ = cw.bufwr.WriteString(pad(data[i], col.outerSize(), col.align, ' '))
+			txt := data[i]
+			if width(txt) > col.outerSize() {
+				txt = truncate(txt, col.outerSize(), TruncateEnd, cw.Ellipsis)
+			}
+			cw.print(pad(txt, col.outerSize(), col.align, ' '))
 		}
 	}
-	_, _ = cw.bufwr.WriteString(cw.spacers[cw.n])
-	_, _ = cw.bufwr.WriteString(strings.Join(suffix, ""))
+	cw.print(cw.spacers[cw.n])
+	cw.print(strings.Join(suffix, ""))
 }
 
-func pad(txt string, size int, align alignment, ch rune) string {
-	l := len([]rune(txt))
+func pad(txt string, size int, align Alignment, ch rune) string {
+	l := width(txt)
 	if l >= size {
 		return txt
 	}